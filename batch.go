@@ -0,0 +1,289 @@
+package enry
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// Item is a file to detect the language of, in a batch.
+type Item struct {
+	// Path is the path of the file, reported in the Result. Only its base
+	// name is used for detection, same as by the enry command, so that the
+	// directories of a file don't change its language.
+	Path string
+	// Load returns the content of the file. It is called by the worker goroutines,
+	// so that files are read in parallel too. If nil, detection is based on Path only.
+	Load func() ([]byte, error)
+	// Hash optionally identifies the content, e.g. by its git blob SHA.
+	// A Detector with a cache uses it to skip Load for content it has seen before.
+	Hash string
+}
+
+// Items is a stream of files to detect the language of, in a batch.
+// Next returns io.EOF once there are no more items.
+type Items interface {
+	Next() (Item, error)
+}
+
+// ItemsFunc is an adapter to use an ordinary function as Items.
+type ItemsFunc func() (Item, error)
+
+// Next implements Items.
+func (f ItemsFunc) Next() (Item, error) {
+	return f()
+}
+
+// SliceItems returns Items streaming the given slice.
+func SliceItems(items []Item) Items {
+	var i int
+	return ItemsFunc(func() (Item, error) {
+		if i >= len(items) {
+			return Item{}, io.EOF
+		}
+		i++
+		return items[i-1], nil
+	})
+}
+
+// Result is the language detected for an Item.
+type Result struct {
+	// Index is the position of the Item in its stream.
+	Index int
+	Path  string
+	// Language is the most probable language, same as GetLanguage returns.
+	Language string
+	// Languages are all the possible languages, same as GetLanguages returns.
+	Languages []string
+	// Status and Strategy tell how the Language was resolved, see Detection.
+	// They are left zero by a Detector with a cache, which only stores the
+	// languages.
+	Status   Status
+	Strategy string
+	// Err is the error loading the content of the Item, if any.
+	Err error
+}
+
+// BatchOptions configures a batch detection. The zero value is ready to use.
+type BatchOptions struct {
+	// Workers is the number of goroutines running detection.
+	// If not positive, runtime.GOMAXPROCS(0) is used.
+	Workers int
+	// Unordered delivers the results as soon as they are ready,
+	// instead of in the order of the items.
+	Unordered bool
+}
+
+func (o BatchOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// DetectAll detects the languages of all the items, running Detect on a
+// pool of worker goroutines. See DetectEach.
+func DetectAll(ctx context.Context, items Items, opts BatchOptions) ([]Result, error) {
+	return detectAll(ctx, items, opts, loadAndDetect(Detect))
+}
+
+// DetectEach detects the languages of all the items, running Detect on a
+// pool of worker goroutines, and calls fn with each Result.
+//
+// fn is never called concurrently, and in the order of the items unless
+// opts.Unordered is set. At most twice as many items as workers are loaded
+// or waiting for fn at any time, whatever the number of items is.
+//
+// Errors loading an item are reported in its Result. DetectEach stops at the
+// first error returned by items.Next or fn, or when ctx is done, and returns it.
+func DetectEach(ctx context.Context, items Items, opts BatchOptions, fn func(Result) error) error {
+	return detectEach(ctx, items, opts, loadAndDetect(Detect), fn)
+}
+
+// DetectAll is the same as the package-level DetectAll, using the Detector configuration.
+func (d *Detector) DetectAll(ctx context.Context, items Items, opts BatchOptions) ([]Result, error) {
+	return detectAll(ctx, items, opts, d.detectItem)
+}
+
+// DetectEach is the same as the package-level DetectEach, using the Detector configuration.
+func (d *Detector) DetectEach(ctx context.Context, items Items, opts BatchOptions, fn func(Result) error) error {
+	return detectEach(ctx, items, opts, d.detectItem, fn)
+}
+
+// detectFunc returns the Detection of an item, loading its content if needed.
+type detectFunc func(item Item) (Detection, error)
+
+func loadAndDetect(detect func(filename string, content []byte) Detection) detectFunc {
+	return func(item Item) (Detection, error) {
+		var content []byte
+		if item.Load != nil {
+			var err error
+			if content, err = item.Load(); err != nil {
+				return Detection{}, err
+			}
+		}
+
+		return detect(filepath.Base(item.Path), content), nil
+	}
+}
+
+func (d *Detector) detectItem(item Item) (Detection, error) {
+	if d.cache == nil {
+		return loadAndDetect(d.Detect)(item)
+	}
+
+	if item.Hash == "" || item.Load == nil {
+		return loadAndDetect(d.detectCached)(item)
+	}
+
+	languages, err := d.GetLanguagesByHash(filepath.Base(item.Path), item.Hash, item.Load)
+	return Detection{Candidates: languages}, err
+}
+
+// detectCached returns a Detection with only the Candidates, which are all
+// the cache of the Detector stores.
+func (d *Detector) detectCached(filename string, content []byte) Detection {
+	return Detection{Candidates: d.GetLanguages(filename, content)}
+}
+
+func detectAll(ctx context.Context, items Items, opts BatchOptions, detect detectFunc) ([]Result, error) {
+	var results []Result
+	err := detectEach(ctx, items, opts, detect, func(r Result) error {
+		results = append(results, r)
+		return nil
+	})
+	return results, err
+}
+
+type job struct {
+	index int
+	item  Item
+}
+
+func detectEach(ctx context.Context, items Items, opts BatchOptions, detect detectFunc, fn func(Result) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := opts.workers()
+	// tokens bound the number of items in flight, from Next until fn returns
+	tokens := make(chan struct{}, 2*workers)
+	jobs := make(chan job)
+	results := make(chan Result)
+
+	var produceErr error
+	produced := make(chan struct{})
+	go func() {
+		defer close(produced)
+		defer close(jobs)
+		for i := 0; ; i++ {
+			select {
+			case tokens <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			item, err := items.Next()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				produceErr = err
+				cancel()
+				return
+			}
+
+			select {
+			case jobs <- job{i, item}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				select {
+				case results <- detectItem(j, detect):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	err := deliver(results, opts.Unordered, func(r Result) error {
+		defer func() { <-tokens }()
+		return fn(r)
+	})
+	if err != nil {
+		cancel()
+	}
+
+	// drain, so that all the goroutines are done before returning
+	for range results {
+	}
+	<-produced
+
+	if err != nil {
+		return err
+	}
+	if produceErr != nil {
+		return produceErr
+	}
+	return ctx.Err()
+}
+
+func detectItem(j job, detect detectFunc) Result {
+	r := Result{Index: j.index, Path: j.item.Path}
+	detection, err := detect(j.item)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	r.Language = firstLanguage(detection.Candidates)
+	r.Languages = detection.Candidates
+	r.Status = detection.Status
+	r.Strategy = detection.Strategy
+	return r
+}
+
+// deliver calls fn with the results, in the order of their Index unless unordered.
+func deliver(results <-chan Result, unordered bool, fn func(Result) error) error {
+	pending := make(map[int]Result)
+	next := 0
+	for r := range results {
+		if unordered {
+			if err := fn(r); err != nil {
+				return err
+			}
+			continue
+		}
+
+		pending[r.Index] = r
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
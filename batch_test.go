@@ -0,0 +1,158 @@
+package enry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-enry/go-enry/v2/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchItems(n int) []Item {
+	items := make([]Item, 0, n)
+	for i := 0; i < n; i++ {
+		i := i
+		items = append(items, Item{
+			Path: fmt.Sprintf("file%d.go", i),
+			Load: func() ([]byte, error) {
+				if i%7 == 0 {
+					// make the results come out of order
+					time.Sleep(time.Millisecond)
+				}
+				return []byte("package main"), nil
+			},
+		})
+	}
+	return items
+}
+
+func TestDetectAll(t *testing.T) {
+	items := batchItems(50)
+	items = append(items, Item{Path: "foo.py"}, Item{
+		Path: "broken.go",
+		Load: func() ([]byte, error) { return nil, errors.New("broken") },
+	})
+
+	results, err := DetectAll(context.Background(), SliceItems(items), BatchOptions{Workers: 4})
+	require.NoError(t, err)
+	require.Len(t, results, len(items))
+
+	for i, r := range results {
+		assert.Equal(t, i, r.Index)
+		assert.Equal(t, items[i].Path, r.Path)
+	}
+	assert.Equal(t, "Go", results[0].Language)
+	assert.Equal(t, []string{"Go"}, results[0].Languages)
+	assert.Equal(t, "Python", results[50].Language)
+	assert.EqualError(t, results[51].Err, "broken")
+	assert.Equal(t, OtherLanguage, results[51].Language)
+}
+
+func TestDetectAllBaseName(t *testing.T) {
+	var filenames []string
+	detect := loadAndDetect(func(filename string, _ []byte) Detection {
+		filenames = append(filenames, filename)
+		return Detection{}
+	})
+	_, err := detect(Item{Path: "build.d/sub/Makefile"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Makefile"}, filenames)
+
+	items := []Item{{Path: "build.d/sub/Makefile"}, {Path: "src/lib.go"}}
+	results, err := DetectAll(context.Background(), SliceItems(items), BatchOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, len(items))
+	assert.Equal(t, "build.d/sub/Makefile", results[0].Path)
+	assert.Equal(t, "Makefile", results[0].Language)
+	assert.Equal(t, Definitive, results[0].Status)
+	assert.Equal(t, "filename", results[0].Strategy)
+	assert.Equal(t, "src/lib.go", results[1].Path)
+	assert.Equal(t, "Go", results[1].Language)
+	assert.Equal(t, "extension", results[1].Strategy)
+}
+
+func TestDetectEachUnordered(t *testing.T) {
+	const n = 50
+	seen := make(map[int]bool)
+	err := DetectEach(context.Background(), SliceItems(batchItems(n)), BatchOptions{Workers: 3, Unordered: true}, func(r Result) error {
+		assert.False(t, seen[r.Index], "result %d delivered twice", r.Index)
+		seen[r.Index] = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, seen, n)
+}
+
+func TestDetectEachBoundedMemory(t *testing.T) {
+	const workers = 2
+	var loaded, delivered int32
+	items := ItemsFunc(func() (Item, error) {
+		if atomic.LoadInt32(&loaded) == 100 {
+			return Item{}, io.EOF
+		}
+		return Item{Path: "a.go", Load: func() ([]byte, error) {
+			in := atomic.AddInt32(&loaded, 1) - atomic.LoadInt32(&delivered)
+			assert.True(t, in <= 2*workers, "%d items in flight", in)
+			return nil, nil
+		}}, nil
+	})
+
+	err := DetectEach(context.Background(), items, BatchOptions{Workers: workers}, func(r Result) error {
+		time.Sleep(100 * time.Microsecond)
+		atomic.AddInt32(&delivered, 1)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestDetectEachErrors(t *testing.T) {
+	stop := errors.New("stop")
+	var calls int
+	err := DetectEach(context.Background(), SliceItems(batchItems(50)), BatchOptions{}, func(r Result) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+
+	failing := ItemsFunc(func() (Item, error) { return Item{}, stop })
+	_, err = DetectAll(context.Background(), failing, BatchOptions{})
+	assert.Equal(t, stop, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = DetectAll(ctx, SliceItems(batchItems(50)), BatchOptions{})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestDetectorDetectAllCache(t *testing.T) {
+	d, err := NewDetector(WithCache(cache.NewLRU(10)))
+	require.NoError(t, err)
+
+	var loads int32
+	items := make([]Item, 20)
+	for i := range items {
+		items[i] = Item{
+			Path: fmt.Sprintf("dir%d/main.go", i),
+			Hash: "blob",
+			Load: func() ([]byte, error) {
+				atomic.AddInt32(&loads, 1)
+				return []byte("package main"), nil
+			},
+		}
+	}
+
+	// a single worker, so that the first item is cached before the others
+	results, err := d.DetectAll(context.Background(), SliceItems(items), BatchOptions{Workers: 1})
+	require.NoError(t, err)
+	for _, r := range results {
+		assert.Equal(t, "Go", r.Language)
+	}
+	assert.Equal(t, int32(1), loads)
+}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/go-enry/go-enry/v2/internal/tokenizer"
+	pub "github.com/go-enry/go-enry/v2/tokenizer"
 )
 
 type sample struct {
@@ -103,6 +107,83 @@ func BenchmarkClassifyTotal(b *testing.B) {
 	})
 }
 
+func BenchmarkTokenizeTotal(b *testing.B) {
+	if slow {
+		b.SkipNow()
+	}
+
+	var o int
+	b.Run("Tokenize()_TOTAL", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, sample := range samples {
+				o = len(tokenizer.Tokenize(sample.content))
+			}
+		}
+	})
+
+	b.Run("TokenizeFunc()_TOTAL", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, sample := range samples {
+				o = 0
+				tokenizer.TokenizeFunc(sample.content, pub.Options{}, func(token []byte) {
+					o += len(token)
+				})
+			}
+		}
+	})
+
+	overcomeLanguage = fmt.Sprint(o)
+}
+
+// BenchmarkTokensLogProbabilityTotal compares scoring a slice of all the
+// tokens, language by language, with scoring the streamed tokens.
+func BenchmarkTokensLogProbabilityTotal(b *testing.B) {
+	if slow {
+		b.SkipNow()
+	}
+
+	nb := defaultClassifier.(*naiveBayes)
+	languages := make([]*scoredLanguage, 0, len(nb.languagesLogProbabilities))
+	for language := range nb.languagesLogProbabilities {
+		languages = append(languages, &scoredLanguage{language: language})
+	}
+
+	var o float64
+	b.Run("tokens_slice_TOTAL", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, sample := range samples {
+				tokens := tokenizer.Tokenize(sample.content)
+				for _, language := range languages {
+					var sum float64
+					for _, token := range tokens {
+						tokenProb, ok := nb.tokensLogProbabilities[language.language][token]
+						if !ok {
+							tokenProb = math.Log(1.000000 / nb.tokensTotal)
+						}
+						sum += tokenProb
+					}
+					o = sum
+				}
+			}
+		}
+	})
+
+	b.Run("streaming_TOTAL", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, sample := range samples {
+				sums, _ := nb.tokensLogProbability(sample.content, languages)
+				o = sums[0]
+			}
+		}
+	})
+
+	overcomeLanguage = fmt.Sprint(o)
+}
+
 func BenchmarkStrategiesTotal(b *testing.B) {
 	if slow {
 		b.SkipNow()
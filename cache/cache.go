@@ -0,0 +1,45 @@
+// Package cache stores the languages detected for files, so that detecting
+// the same content again skips tokenization and classification.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+)
+
+// Key identifies the detection of a file. Detection only depends on the
+// base name of a file, its content and the configuration of the detector,
+// so two files with the same name and content share a Key whatever
+// directory they are in.
+type Key struct {
+	// Name is the base name of the file.
+	Name string
+	// Hash identifies the content of the file, e.g. a git blob SHA.
+	Hash string
+	// Config identifies the configuration of the detector, e.g. its regex
+	// engine and classifier, so that detectors configured differently can
+	// share a Cache. It is set by the enry Detector.
+	Config string
+}
+
+// NewKey returns the Key of a file whose content has the given hash.
+// Any hash works, as long as the same one is always used for a Cache.
+func NewKey(filename, hash string) Key {
+	return Key{Name: filepath.Base(filename), Hash: hash}
+}
+
+// KeyOf returns the Key of a file, hashing its content with SHA-256.
+func KeyOf(filename string, content []byte) Key {
+	sum := sha256.Sum256(content)
+	return NewKey(filename, hex.EncodeToString(sum[:]))
+}
+
+// Cache stores the languages detected for each Key.
+// Implementations must be safe for concurrent use by multiple goroutines.
+type Cache interface {
+	// Get returns the languages stored for the key, if any.
+	Get(key Key) (languages []string, ok bool)
+	// Put stores the languages detected for the key.
+	Put(key Key, languages []string)
+}
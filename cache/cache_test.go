@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	content := []byte("package main")
+	assert.Equal(t, KeyOf("a/b/main.go", content), KeyOf("c/main.go", content))
+	assert.NotEqual(t, KeyOf("main.go", content), KeyOf("main.c", content))
+	assert.NotEqual(t, KeyOf("main.go", content), KeyOf("main.go", []byte("package foo")))
+	assert.Equal(t, Key{Name: "main.go", Hash: "abc"}, NewKey("cmd/main.go", "abc"))
+}
+
+func testCache(t *testing.T, c Cache) {
+	foo := NewKey("foo.h", "1")
+	_, ok := c.Get(foo)
+	assert.False(t, ok)
+
+	c.Put(foo, []string{"C", "C++"})
+	languages, ok := c.Get(foo)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"C", "C++"}, languages)
+
+	// no language detected is cached too
+	none := NewKey("foo", "2")
+	c.Put(none, nil)
+	languages, ok = c.Get(none)
+	assert.True(t, ok)
+	assert.Empty(t, languages)
+
+	c.Put(foo, []string{"Objective-C"})
+	languages, ok = c.Get(foo)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Objective-C"}, languages)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := NewKey("bar.go", fmt.Sprint(i%2))
+			c.Put(key, []string{"Go"})
+			languages, ok := c.Get(key)
+			assert.True(t, ok)
+			assert.Equal(t, []string{"Go"}, languages)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestLRU(t *testing.T) {
+	testCache(t, NewLRU(10))
+
+	c := NewLRU(2)
+	c.Put(NewKey("a", "1"), []string{"A"})
+	c.Put(NewKey("b", "1"), []string{"B"})
+	_, ok := c.Get(NewKey("a", "1"))
+	require.True(t, ok)
+
+	c.Put(NewKey("c", "1"), []string{"C"})
+	assert.Equal(t, 2, c.Len())
+	_, ok = c.Get(NewKey("b", "1"))
+	assert.False(t, ok, "least recently used key must be evicted")
+	_, ok = c.Get(NewKey("a", "1"))
+	assert.True(t, ok)
+
+	languages, _ := c.Get(NewKey("c", "1"))
+	languages[0] = "D"
+	languages, _ = c.Get(NewKey("c", "1"))
+	assert.Equal(t, []string{"C"}, languages)
+
+	assert.Panics(t, func() { NewLRU(0) })
+}
+
+func TestDisk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enry-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := NewDisk(dir)
+	require.NoError(t, err)
+	testCache(t, c)
+
+	// entries are kept between runs
+	c, err = NewDisk(dir)
+	require.NoError(t, err)
+	languages, ok := c.Get(NewKey("foo.h", "1"))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Objective-C"}, languages)
+
+	// detectors configured differently don't share entries
+	key := NewKey("foo.h", "1")
+	key.Config = "other"
+	_, ok = c.Get(key)
+	assert.False(t, ok)
+
+	tmp, err := filepath.Glob(filepath.Join(dir, "*", "*", ".tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, tmp)
+}
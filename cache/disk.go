@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2/data"
+)
+
+// Disk is a Cache storing every key in its own file under a directory, so
+// that it can be shared by many processes and kept between runs, e.g. in CI.
+//
+// Entries are kept per linguist version, so upgrading enry never returns
+// languages detected by a previous version. Disk is a best effort cache:
+// errors reading and writing entries are treated as cache misses.
+type Disk struct {
+	dir string
+}
+
+// NewDisk returns a Disk cache storing its entries under dir,
+// which is created if it does not exist.
+func NewDisk(dir string) (*Disk, error) {
+	dir = filepath.Join(dir, data.LinguistCommit)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Disk{dir: dir}, nil
+}
+
+// Get implements Cache.
+func (c *Disk) Get(key Key) ([]string, bool) {
+	content, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	if len(content) == 0 {
+		return nil, true
+	}
+
+	return strings.Split(string(content), "\n"), true
+}
+
+// Put implements Cache.
+func (c *Disk) Put(key Key, languages []string) {
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+
+	// write to a temporary file first, so that concurrent readers never see
+	// a partial entry
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return
+	}
+
+	_, err = f.WriteString(strings.Join(languages, "\n"))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// path returns the file of the key, spread over subdirectories
+// named after the first byte of its hash.
+func (c *Disk) path(key Key) string {
+	sum := sha256.Sum256([]byte(key.Name + "\x00" + key.Hash + "\x00" + key.Config))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, name[:2], name[2:])
+}
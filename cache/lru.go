@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU is an in-memory Cache holding a fixed number of keys,
+// evicting the least recently used ones first.
+type LRU struct {
+	size int
+
+	mu    sync.Mutex
+	order *list.List
+	items map[Key]*list.Element
+}
+
+type lruEntry struct {
+	key       Key
+	languages []string
+}
+
+// NewLRU returns an LRU holding up to size keys. It panics if size is not positive.
+func NewLRU(size int) *LRU {
+	if size <= 0 {
+		panic("cache: NewLRU size must be positive")
+	}
+
+	return &LRU{
+		size:  size,
+		order: list.New(),
+		items: make(map[Key]*list.Element, size),
+	}
+}
+
+// Get implements Cache.
+func (c *LRU) Get(key Key) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	return clone(e.Value.(*lruEntry).languages), true
+}
+
+// Put implements Cache.
+func (c *LRU) Put(key Key, languages []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruEntry).languages = clone(languages)
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key, clone(languages)})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of keys in the cache.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// clone copies languages, so that callers can not modify what is cached.
+func clone(languages []string) []string {
+	if languages == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(languages)), languages...)
+}
@@ -0,0 +1,199 @@
+package enry
+
+import (
+	"math"
+	"sync"
+)
+
+// Calibration turns the scores of a Naive Bayes classifier into
+// probabilities. The raw likelihoods of the candidates are products of the
+// probabilities of every token, so they get more extreme the longer the
+// content is, and are close to 0 or 1 regardless of how likely the
+// classifier is to be right. Calibrated probabilities are the softmax of the
+// log likelihoods per token divided by the Temperature, fitted so that they
+// match how often the classifier is right on samples it wasn't trained on.
+type Calibration struct {
+	// Temperature scales the log likelihoods per token of the candidates,
+	// the higher the less confident the probabilities. 0 leaves the
+	// likelihoods uncalibrated.
+	Temperature float64
+}
+
+// probabilities returns the softmax of the calibrated scores of the known
+// candidates of some content with the given number of tokens. The unknown
+// candidates have a probability of 0.
+func (c Calibration) probabilities(scores []float64, known []bool, tokens int) []float64 {
+	scale := 1.0
+	if c.Temperature > 0 {
+		if tokens < 1 {
+			tokens = 1
+		}
+		scale = 1 / (float64(tokens) * c.Temperature)
+	}
+
+	probabilities := make([]float64, len(scores))
+	max := math.Inf(-1)
+	for i, score := range scores {
+		if known[i] && score > max {
+			max = score
+		}
+	}
+
+	var total float64
+	for i, score := range scores {
+		if !known[i] {
+			continue
+		}
+		probabilities[i] = math.Exp((score - max) * scale)
+		total += probabilities[i]
+	}
+
+	for i := range probabilities {
+		if total > 0 {
+			probabilities[i] /= total
+		}
+	}
+
+	return probabilities
+}
+
+// Calibrator fits the Calibration of a Naive Bayes classifier on samples it
+// wasn't trained on, e.g. the held-out folds of a cross-validation, each
+// scored by the model trained on the other folds. It is safe for concurrent use.
+type Calibrator struct {
+	mu      sync.Mutex
+	samples []calibrationSample
+}
+
+// calibrationSample is a held-out sample scored by a model.
+type calibrationSample struct {
+	// scores are the log likelihoods of the candidates, the expected language first.
+	scores []float64
+	tokens int
+}
+
+// Add scores content with m among the candidates, to fit the Calibration on.
+// Candidates unknown to m are ignored, and so is content whose language is
+// not one of the remaining candidates or is the only one.
+func (c *Calibrator) Add(m NaiveBayesModel, language string, content []byte, candidates []string) {
+	nb := &naiveBayes{
+		languagesLogProbabilities: m.LanguagesLogProbabilities,
+		tokensLogProbabilities:    m.TokensLogProbabilities,
+		tokensTotal:               m.TokensTotal,
+	}
+
+	if lang, ok := GetLanguageByAlias(language); ok {
+		language = lang
+	}
+
+	scoredLangs := []*scoredLanguage{{language: language}}
+	seen := map[string]bool{language: true}
+	for _, candidate := range candidates {
+		if lang, ok := GetLanguageByAlias(candidate); ok {
+			candidate = lang
+		}
+
+		if _, ok := m.LanguagesLogProbabilities[candidate]; !ok || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		scoredLangs = append(scoredLangs, &scoredLanguage{language: candidate})
+	}
+
+	if _, ok := m.LanguagesLogProbabilities[language]; !ok || len(scoredLangs) < 2 || !containsCandidate(candidates, language) {
+		return
+	}
+
+	tokens := nb.score(content, scoredLangs)
+	scores := make([]float64, len(scoredLangs))
+	for i, scoredLang := range scoredLangs {
+		scores[i] = scoredLang.score
+	}
+
+	c.mu.Lock()
+	c.samples = append(c.samples, calibrationSample{scores: scores, tokens: tokens})
+	c.mu.Unlock()
+}
+
+func containsCandidate(candidates []string, language string) bool {
+	for _, candidate := range candidates {
+		if lang, ok := GetLanguageByAlias(candidate); ok {
+			candidate = lang
+		}
+		if candidate == language {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of samples added.
+func (c *Calibrator) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.samples)
+}
+
+// LogLoss returns the mean negative log probability cal gives to the
+// language of the samples added.
+func (c *Calibrator) LogLoss(cal Calibration) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.samples) == 0 {
+		return 0
+	}
+
+	var loss float64
+	for _, s := range c.samples {
+		known := make([]bool, len(s.scores))
+		for i := range known {
+			known[i] = true
+		}
+
+		p := cal.probabilities(s.scores, known, s.tokens)[0]
+		loss -= math.Log(math.Max(p, minProbability))
+	}
+
+	return loss / float64(len(c.samples))
+}
+
+// minProbability bounds the log loss of a single sample.
+const minProbability = 1e-15
+
+// Bounds of the natural log of the temperatures Fit searches.
+const (
+	minLogTemperature = -12
+	maxLogTemperature = 6
+)
+
+// Fit returns the Calibration minimizing the log loss of the samples added,
+// or the zero Calibration if there are none. The log loss is convex in the
+// inverse of the temperature, so it's found by a golden-section search.
+func (c *Calibrator) Fit() Calibration {
+	if c.Len() == 0 {
+		return Calibration{}
+	}
+
+	loss := func(logTemperature float64) float64 {
+		return c.LogLoss(Calibration{Temperature: math.Exp(logTemperature)})
+	}
+
+	invPhi := (math.Sqrt(5) - 1) / 2
+	a, b := float64(minLogTemperature), float64(maxLogTemperature)
+	x1, x2 := b-invPhi*(b-a), a+invPhi*(b-a)
+	f1, f2 := loss(x1), loss(x2)
+	for b-a > 1e-4 {
+		if f1 <= f2 {
+			b, x2, f2 = x2, x1, f1
+			x1 = b - invPhi*(b-a)
+			f1 = loss(x1)
+		} else {
+			a, x1, f1 = x1, x2, f2
+			x2 = a + invPhi*(b-a)
+			f2 = loss(x2)
+		}
+	}
+
+	return Calibration{Temperature: math.Exp((a + b) / 2)}
+}
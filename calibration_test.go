@@ -0,0 +1,121 @@
+package enry
+
+import (
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-enry/go-enry/v2/data"
+	"github.com/go-enry/go-enry/v2/tokenizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalibrationProbabilities(t *testing.T) {
+	scores := []float64{-10, -20, -5}
+	known := []bool{true, true, false}
+
+	uncalibrated := Calibration{}.probabilities(scores, known, 10)
+	assert.InDelta(t, 1/(1+math.Exp(-10)), uncalibrated[0], 1e-9)
+	assert.Equal(t, 0.0, uncalibrated[2])
+
+	calibrated := Calibration{Temperature: 1}.probabilities(scores, known, 10)
+	assert.InDelta(t, 1/(1+math.Exp(-1)), calibrated[0], 1e-9)
+	assert.InDelta(t, 1, calibrated[0]+calibrated[1], 1e-9)
+	assert.Equal(t, 0.0, calibrated[2])
+
+	empty := Calibration{Temperature: 1}.probabilities(scores, known, 0)
+	assert.InDelta(t, 1/(1+math.Exp(-10)), empty[0], 1e-9)
+}
+
+func TestCalibratorFit(t *testing.T) {
+	const temperature = 0.5
+	r := rand.New(rand.NewSource(1))
+
+	c := &Calibrator{}
+	assert.Equal(t, Calibration{}, c.Fit())
+
+	for i := 0; i < 5000; i++ {
+		tokens := 1 + r.Intn(100)
+		diff := r.NormFloat64() * float64(tokens)
+		scores := []float64{diff, 0}
+		if r.Float64() >= 1/(1+math.Exp(-diff/(float64(tokens)*temperature))) {
+			scores = []float64{0, diff}
+		}
+		c.samples = append(c.samples, calibrationSample{scores: scores, tokens: tokens})
+	}
+
+	cal := c.Fit()
+	assert.InDelta(t, temperature, cal.Temperature, 0.05)
+	assert.True(t, c.LogLoss(cal) < c.LogLoss(Calibration{}))
+	assert.True(t, c.LogLoss(cal) <= c.LogLoss(Calibration{Temperature: 2 * cal.Temperature}))
+}
+
+func TestCalibratorAdd(t *testing.T) {
+	m := NaiveBayesModel{
+		LanguagesLogProbabilities: map[string]float64{"Foo": math.Log(0.5), "Bar": math.Log(0.5)},
+		TokensLogProbabilities: map[string]map[string]float64{
+			"Foo": {"foo": -0.1, "bar": -3},
+			"Bar": {"foo": -3, "bar": -0.1},
+		},
+		TokensTotal: 10,
+	}
+	content := []byte(strings.Repeat("foo\n", 10) + "bar\n")
+
+	c := &Calibrator{}
+	c.Add(m, "Foo", content, []string{"Foo"})
+	c.Add(m, "Foo", content, []string{"Bar", "Unknown"})
+	c.Add(m, "Unknown", content, []string{"Foo", "Bar"})
+	assert.Equal(t, 0, c.Len())
+
+	c.Add(m, "Foo", content, []string{"Bar", "Foo", "Unknown"})
+	c.Add(m, "Bar", content, []string{"Foo", "Bar"})
+	require.Equal(t, 2, c.Len())
+	assert.InDelta(t, -0.1*10-3, c.samples[0].scores[0]-math.Log(0.5), 1e-9)
+	assert.Equal(t, 11, c.samples[0].tokens)
+
+	m.Calibration = c.Fit()
+	assert.True(t, m.Calibration.Temperature > 0)
+
+	p := NewNaiveBayesWithModel(m, tokenizer.Options{}).Probabilities(content, []string{"Foo", "Bar", "Unknown"})
+	assert.InDelta(t, 1, p[0]+p[1], 1e-9)
+	assert.True(t, p[0] > p[1])
+	assert.Equal(t, 0.0, p[2])
+}
+
+func (s *enryTestSuite) TestShippedCalibration() {
+	nb := defaultClassifier.(*naiveBayes)
+	require.True(s.T(), data.ClassifierTemperature >= 0)
+	assert.Equal(s.T(), data.ClassifierTemperature, nb.calibration.Temperature)
+
+	// whatever the temperature, the probabilities of the candidates are
+	// bounded, add up to 1 and follow the order of their likelihoods
+	candidates := []string{"C", "C++", "Python", "Ruby"}
+	for _, sample := range []string{"C/blob.c", "C++/runtime-compiler.cc", "Python/django-models-base.py"} {
+		content, err := ioutil.ReadFile(filepath.Join(s.samplesDir, sample))
+		require.NoError(s.T(), err)
+
+		probabilities := nb.Probabilities(content, candidates)
+		byLanguage := make(map[string]float64, len(candidates))
+		var total float64
+		for i, p := range probabilities {
+			assert.True(s.T(), p >= 0 && p <= 1, "%s: %s %v", sample, candidates[i], p)
+			byLanguage[candidates[i]] = p
+			total += p
+		}
+		assert.InDelta(s.T(), 1, total, 1e-9, sample)
+
+		weights := make(map[string]float64, len(candidates))
+		for _, candidate := range candidates {
+			weights[candidate] = 1
+		}
+		ranked := nb.classify(content, weights)
+		assert.Equal(s.T(), filepath.Dir(sample), ranked[0], sample)
+		for i := 1; i < len(ranked); i++ {
+			assert.True(s.T(), byLanguage[ranked[i-1]] >= byLanguage[ranked[i]], "%s: %v", sample, ranked)
+		}
+	}
+}
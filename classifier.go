@@ -4,7 +4,9 @@ import (
 	"math"
 	"sort"
 
+	"github.com/go-enry/go-enry/v2/data"
 	"github.com/go-enry/go-enry/v2/internal/tokenizer"
+	pub "github.com/go-enry/go-enry/v2/tokenizer"
 )
 
 // classifier is the interface in charge to detect the possible languages of the given content based on a set of
@@ -17,6 +19,117 @@ type naiveBayes struct {
 	languagesLogProbabilities map[string]float64
 	tokensLogProbabilities    map[string]map[string]float64
 	tokensTotal               float64
+	calibration               Calibration
+	// tokenizer selects the parts of the content that are tokenized
+	tokenizer pub.Options
+}
+
+// newNaiveBayes returns a naiveBayes trained on Linguist samples,
+// tokenizing content with the given options.
+func newNaiveBayes(opts pub.Options) *naiveBayes {
+	return &naiveBayes{
+		languagesLogProbabilities: data.LanguagesLogProbabilities,
+		tokensLogProbabilities:    data.TokensLogProbabilities,
+		tokensTotal:               data.TokensTotal,
+		calibration:               Calibration{Temperature: data.ClassifierTemperature},
+		tokenizer:                 opts,
+	}
+}
+
+// NaiveBayesModel is what a Naive Bayes Classifier learns from samples.
+// The one learnt from all the Linguist samples is generated in the data
+// package, e.g. data.LanguagesLogProbabilities.
+type NaiveBayesModel struct {
+	// LanguagesLogProbabilities is the log probability of a sample being of each language.
+	LanguagesLogProbabilities map[string]float64
+	// TokensLogProbabilities is the log probability of every token of each language.
+	TokensLogProbabilities map[string]map[string]float64
+	// TokensTotal is the number of tokens of all the samples.
+	TokensTotal float64
+	// Calibration turns the scores of the model into probabilities, see Calibrator.
+	Calibration Calibration
+}
+
+// NewNaiveBayesWithModel is the same as NewNaiveBayes, with a model learnt from other samples.
+func NewNaiveBayesWithModel(m NaiveBayesModel, opts pub.Options) Classifier {
+	return &naiveBayes{
+		languagesLogProbabilities: m.LanguagesLogProbabilities,
+		tokensLogProbabilities:    m.TokensLogProbabilities,
+		tokensTotal:               m.TokensTotal,
+		calibration:               m.Calibration,
+		tokenizer:                 opts,
+	}
+}
+
+// Classifier scores the candidate languages of some content. Unlike the
+// classifier used by the strategies, it returns the probability of every
+// candidate, so that the outputs of several of them can be combined, see
+// Ensemble.
+type Classifier interface {
+	// Probabilities returns the probability of each of the candidates being
+	// the language of content, assuming it is one of them. Candidates unknown
+	// to the Classifier have a probability of 0.
+	Probabilities(content []byte, candidates []string) []float64
+}
+
+// NewNaiveBayes returns the Naive Bayes Classifier used by GetLanguagesByClassifier,
+// tokenizing content with the given options. Its probabilities are the
+// likelihoods of the candidates calibrated with data.ClassifierTemperature,
+// see Calibration.
+func NewNaiveBayes(opts pub.Options) Classifier {
+	return newNaiveBayes(opts)
+}
+
+// ClassifierStrategy returns a Strategy sorting the candidates by decreasing
+// probability according to c, e.g. to replace GetLanguagesByClassifier in a
+// custom list of strategies.
+func ClassifierStrategy(c Classifier) Strategy {
+	return func(_ string, content []byte, candidates []string) []string {
+		if len(candidates) == 0 {
+			return nil
+		}
+
+		return getLanguagesBySpecificClassifier(content, candidates, probabilisticClassifier{c})
+	}
+}
+
+// probabilisticClassifier is a classifier sorting the candidates by the probabilities of a Classifier.
+type probabilisticClassifier struct {
+	Classifier
+}
+
+// classify returns the candidates sorted by decreasing probability, the ones unknown to the Classifier last.
+func (c probabilisticClassifier) classify(content []byte, candidates map[string]float64) []string {
+	languages := make([]string, 0, len(candidates))
+	for candidate := range candidates {
+		languages = append(languages, candidate)
+	}
+
+	return sortLanguagesByScore(rankCandidates(c.Classifier, content, languages))
+}
+
+// rankCandidates returns the alias-resolved candidates scored by their
+// probability according to c, sorted by decreasing probability and then
+// alphabetically.
+func rankCandidates(c Classifier, content []byte, candidates []string) []*scoredLanguage {
+	languages := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if lang, ok := GetLanguageByAlias(candidate); ok {
+			candidate = lang
+		}
+
+		languages = append(languages, candidate)
+	}
+	sort.Strings(languages)
+
+	probabilities := c.Probabilities(content, languages)
+	scoredLangs := make([]*scoredLanguage, len(languages))
+	for i, language := range languages {
+		scoredLangs[i] = &scoredLanguage{language: language, score: probabilities[i]}
+	}
+
+	sort.Stable(byScore(scoredLangs))
+	return scoredLangs
 }
 
 type scoredLanguage struct {
@@ -24,7 +137,8 @@ type scoredLanguage struct {
 	score    float64
 }
 
-// classify returns a sorted slice of possible languages sorted by decreasing language's probability
+// classify returns a sorted slice of possible languages sorted by decreasing language's probability,
+// the ones unknown to the model last.
 func (c *naiveBayes) classify(content []byte, candidates map[string]float64) []string {
 
 	var languages map[string]float64
@@ -41,26 +155,60 @@ func (c *naiveBayes) classify(content []byte, candidates map[string]float64) []s
 		}
 	}
 
-	empty := len(content) == 0
 	scoredLangs := make([]*scoredLanguage, 0, len(languages))
+	for language := range languages {
+		scoredLangs = append(scoredLangs, &scoredLanguage{language: language})
+	}
+	// ties are broken alphabetically, not by the order of the map, so that
+	// the same content is always detected as the same language
+	sort.Slice(scoredLangs, func(i, j int) bool { return scoredLangs[i].language < scoredLangs[j].language })
 
-	var tokens []string
-	if !empty {
-		tokens = tokenizer.Tokenize(content)
+	c.score(content, scoredLangs)
+	return sortLanguagesByScore(scoredLangs)
+}
+
+// Probabilities implements Classifier.
+func (c *naiveBayes) Probabilities(content []byte, candidates []string) []float64 {
+	scoredLangs := make([]*scoredLanguage, len(candidates))
+	for i, candidate := range candidates {
+		if lang, ok := GetLanguageByAlias(candidate); ok {
+			candidate = lang
+		}
+
+		scoredLangs[i] = &scoredLanguage{language: candidate}
 	}
 
-	for language := range languages {
-		score := c.languagesLogProbabilities[language]
-		if !empty {
-			score += c.tokensLogProbability(tokens, language)
+	tokens := c.score(content, scoredLangs)
+	known := make([]bool, len(candidates))
+	scores := make([]float64, len(candidates))
+	for i, scoredLang := range scoredLangs {
+		_, known[i] = c.languagesLogProbabilities[scoredLang.language]
+		scores[i] = scoredLang.score
+	}
+
+	return c.calibration.probabilities(scores, known, tokens)
+}
+
+// score sets the score of each of the languages to its log probability
+// for content, and returns the number of tokens of content. Languages unknown
+// to the model score -Inf, so that they rank last as they do by probability.
+func (c *naiveBayes) score(content []byte, scoredLangs []*scoredLanguage) int {
+	for _, scoredLang := range scoredLangs {
+		var ok bool
+		if scoredLang.score, ok = c.languagesLogProbabilities[scoredLang.language]; !ok {
+			scoredLang.score = math.Inf(-1)
 		}
-		scoredLangs = append(scoredLangs, &scoredLanguage{
-			language: language,
-			score:    score,
-		})
 	}
 
-	return sortLanguagesByScore(scoredLangs)
+	if len(content) == 0 {
+		return 0
+	}
+
+	sums, tokens := c.tokensLogProbability(content, scoredLangs)
+	for i, sum := range sums {
+		scoredLangs[i].score += sum
+	}
+	return tokens
 }
 
 func sortLanguagesByScore(scoredLangs []*scoredLanguage) []string {
@@ -82,22 +230,31 @@ func (c *naiveBayes) knownLangs() map[string]float64 {
 	return langs
 }
 
-func (c *naiveBayes) tokensLogProbability(tokens []string, language string) float64 {
-	var sum float64
-	for _, token := range tokens {
-		sum += c.tokenProbability(token, language)
+// tokensLogProbability returns the sum of the log probabilities of the tokens
+// of content for each of the languages, and the number of tokens. Tokens are streamed from the tokenizer
+// and scored for all the languages at once, so content is tokenized only once
+// and tokens are never copied.
+func (c *naiveBayes) tokensLogProbability(content []byte, languages []*scoredLanguage) ([]float64, int) {
+	probabilities := make([]map[string]float64, len(languages))
+	for i, language := range languages {
+		probabilities[i] = c.tokensLogProbabilities[language.language]
 	}
 
-	return sum
-}
-
-func (c *naiveBayes) tokenProbability(token, language string) float64 {
-	tokenProb, ok := c.tokensLogProbabilities[language][token]
-	if !ok {
-		tokenProb = math.Log(1.000000 / c.tokensTotal)
-	}
+	unknown := math.Log(1.000000 / c.tokensTotal)
+	sums := make([]float64, len(languages))
+	var tokens int
+	tokenizer.TokenizeFunc(content, c.tokenizer, func(token []byte) {
+		tokens++
+		for i, tokensLogProbabilities := range probabilities {
+			tokenProb, ok := tokensLogProbabilities[string(token)]
+			if !ok {
+				tokenProb = unknown
+			}
+			sums[i] += tokenProb
+		}
+	})
 
-	return tokenProb
+	return sums, tokens
 }
 
 type byScore []*scoredLanguage
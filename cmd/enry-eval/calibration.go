@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+// calibration is the Calibration fitted on the samples reaching the classifier.
+type calibration struct {
+	Samples     int     `json:"samples"`
+	Temperature float64 `json:"temperature"`
+	// LogLoss and UncalibratedLogLoss are the mean negative log probability
+	// of the language of the samples, with and without the calibration.
+	LogLoss             float64 `json:"log_loss"`
+	UncalibratedLogLoss float64 `json:"uncalibrated_log_loss"`
+}
+
+func newCalibration(c *enry.Calibrator) *calibration {
+	cal := c.Fit()
+	return &calibration{
+		Samples:             c.Len(),
+		Temperature:         cal.Temperature,
+		LogLoss:             c.LogLoss(cal),
+		UncalibratedLogLoss: c.LogLoss(enry.Calibration{}),
+	}
+}
+
+// writeCalibration writes the Go source of data.ClassifierTemperature to path.
+func writeCalibration(path string, c *calibration) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `// Code generated by github.com/go-enry/go-enry/v2/cmd/enry-eval DO NOT EDIT.
+
+package data
+
+// ClassifierTemperature calibrates the probabilities of the classifier
+// learnt from the Linguist samples, see enry.Calibration. It is fitted on
+// held-out samples by `+"`enry-eval -calibrate`"+`; 0 leaves them uncalibrated.
+var ClassifierTemperature = %s
+`, formatTemperature(c.Temperature))
+
+	content, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+// formatTemperature formats t as a floating-point constant, so that the
+// variable it is assigned to is a float64 even if t is an integer.
+func formatTemperature(t float64) string {
+	s := strconv.FormatFloat(t, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/internal/crossval"
+	pub "github.com/go-enry/go-enry/v2/tokenizer"
+)
+
+// prediction is the language detected for a sample.
+type prediction struct {
+	path      string
+	fold      int
+	expected  string
+	predicted string
+}
+
+// crossValidate detects the language of every sample with a classifier
+// trained on the samples of the other folds. If cal isn't nil, the samples
+// reaching the classifier are added to it with the candidates left.
+func crossValidate(samples []*crossval.Sample, k int, classifierOnly bool, cal *enry.Calibrator) ([]prediction, error) {
+	var languages []string
+	if classifierOnly {
+		seen := make(map[string]bool)
+		for _, s := range samples {
+			if !seen[s.Language] {
+				seen[s.Language] = true
+				languages = append(languages, s.Language)
+			}
+		}
+	}
+
+	predictions := make([]prediction, len(samples))
+	err := crossval.Run(samples, k, func(fold int, train []*crossval.Sample, test []int) error {
+		m := crossval.TrainNaiveBayes(train)
+		nb := enry.NewNaiveBayesWithModel(m, pub.Options{})
+		detect, err := detector(nb, languages)
+		if err != nil {
+			return err
+		}
+
+		errs := make([]error, len(test))
+		crossval.Parallel(len(test), func(j int) {
+			s := samples[test[j]]
+			detect := detect
+			if cal != nil {
+				detect, errs[j] = detector(calibrating{nb, cal, m, s.Language}, languages)
+				if errs[j] != nil {
+					return
+				}
+			}
+
+			predictions[test[j]] = prediction{
+				path:      s.Path,
+				fold:      fold,
+				expected:  s.Language,
+				predicted: detect(filepath.Base(s.Path), s.Content),
+			}
+		})
+
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return predictions, nil
+}
+
+// calibrating is a Classifier adding the content it classifies, of the given
+// language, to a Calibrator of the model of the Classifier.
+type calibrating struct {
+	enry.Classifier
+	calibrator *enry.Calibrator
+	model      enry.NaiveBayesModel
+	language   string
+}
+
+func (c calibrating) Probabilities(content []byte, candidates []string) []float64 {
+	c.calibrator.Add(c.model, c.language, content, candidates)
+	return c.Classifier.Probabilities(content, candidates)
+}
+
+// detector returns the function detecting the language of a sample: all the
+// strategies of a Detector using c, or c alone among the given languages if
+// there are any.
+func detector(c enry.Classifier, languages []string) (func(string, []byte) string, error) {
+	if len(languages) != 0 {
+		strategy := enry.ClassifierStrategy(c)
+		return func(filename string, content []byte) string {
+			return strategy(filename, content, languages)[0]
+		}, nil
+	}
+
+	d, err := enry.NewDetector(enry.WithClassifier(c))
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetLanguage, nil
+}
@@ -0,0 +1,81 @@
+// Command enry-eval measures the accuracy of enry on Linguist samples by
+// k-fold cross-validation: the Naive Bayes classifier is trained again on all
+// the folds but one, the same way data/frequencies.go is generated, and enry
+// detects the language of the samples of the fold left out. With -calibrate,
+// the temperature calibrating the probabilities of the classifier is fitted
+// on the samples of the folds left out, and written as data/calibration.go.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/internal/crossval"
+)
+
+func main() {
+	log.SetFlags(0)
+	flag.Usage = usage
+	k := flag.Int("k", 5, "the number of folds")
+	classifierOnly := flag.Bool("classifier-only", false, "classify the samples among all the languages instead of running all the strategies")
+	out := flag.String("out", "", "the directory to write report.json, languages.csv, confusion.csv and pairs.csv to")
+	top := flag.Int("top", 20, "the number of most confused pairs to show")
+	calibrate := flag.String("calibrate", "", "the file to write the classifier calibration fitted on the held-out samples reaching the classifier to, e.g. data/calibration.go")
+	flag.Parse()
+
+	if flag.NArg() != 1 || *k < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	// the classifier ranks the candidates the other strategies leave, so it
+	// is calibrated on those, as make code-generate does
+	if *calibrate != "" && *classifierOnly {
+		log.Fatal("-calibrate can't be used with -classifier-only")
+	}
+
+	samples, err := crossval.ReadSamples(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var calibrator *enry.Calibrator
+	if *calibrate != "" {
+		calibrator = &enry.Calibrator{}
+	}
+
+	predictions, err := crossValidate(samples, *k, *classifierOnly, calibrator)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := newReport(*k, predictions)
+	if calibrator != nil {
+		r.Calibration = newCalibration(calibrator)
+		if err := writeCalibration(*calibrate, r.Calibration); err != nil {
+			log.Fatal(err)
+		}
+	}
+	printSummary(os.Stdout, r, *top)
+
+	if *out != "" {
+		if err := writeReport(*out, r); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func usage() {
+	fmt.Fprintf(
+		os.Stderr,
+		`  %[1]s, cross-validates enry on Linguist samples
+  usage: %[1]s [-k=N] [-classifier-only] [-top=N] [-out=<dir>] [-calibrate=<file>] <samples>
+`,
+		filepath.Base(os.Args[0]),
+	)
+	flag.PrintDefaults()
+}
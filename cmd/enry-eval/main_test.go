@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/data"
+	"github.com/go-enry/go-enry/v2/internal/crossval"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	predictions := []prediction{
+		{path: "a.c", fold: 0, expected: "C", predicted: "C"},
+		{path: "b.c", fold: 1, expected: "C", predicted: "C++"},
+		{path: "a.cpp", fold: 0, expected: "C++", predicted: "C++"},
+		{path: "b.cpp", fold: 1, expected: "C++", predicted: "C++"},
+		{path: "c.cpp", fold: 1, expected: "C++", predicted: ""},
+	}
+
+	r := newReport(2, predictions)
+	assert.Equal(t, 5, r.Samples)
+	assert.Equal(t, 3, r.Correct)
+	assert.InDelta(t, 0.6, r.Accuracy, 1e-9)
+	assert.InDeltaSlice(t, []float64{1, 1.0 / 3}, r.FoldAccuracy, 1e-9)
+	assert.Equal(t, []confusedPair{{"C", "C++", 1}, {"C++", noLanguage, 1}}, r.ConfusedPairs)
+	assert.Equal(t, map[string]map[string]int{
+		"C":   {"C": 1, "C++": 1},
+		"C++": {"C++": 2, noLanguage: 1},
+	}, r.Confusion)
+
+	require.Len(t, r.Languages, 3)
+	assert.Equal(t, "(none)", r.Languages[0].Language)
+	c, cpp := r.Languages[1], r.Languages[2]
+	assert.Equal(t, languageMetrics{Language: "C", Support: 2, Predicted: 1, TruePositives: 1, Precision: 1, Recall: 0.5, F1: 2.0 / 3}, c)
+	assert.InDelta(t, 2.0/3, cpp.Precision, 1e-9)
+	assert.InDelta(t, 2.0/3, cpp.Recall, 1e-9)
+	assert.InDelta(t, (c.F1+cpp.F1)/2, r.MacroF1, 1e-9)
+
+	assert.Equal(t, [][]string{
+		{"expected\\predicted", "(none)", "C", "C++"},
+		{"C", "0", "1", "1"},
+		{"C++", "1", "0", "2"},
+	}, confusionTable(r))
+
+	var buf bytes.Buffer
+	printSummary(&buf, r, 1)
+	assert.Equal(t, `samples: 5, correct: 3, accuracy: 60.00%
+macro precision: 0.8333, macro recall: 0.5833, macro F1: 0.6667
+fold 0: 100.00%
+fold 1: 33.33%
+
+most confused:
+expected  predicted  count
+C         C++        1
+`, buf.String())
+}
+
+func TestCrossValidate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enry-eval")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"C/a.c":       "#include <stdio.h>\nint main(void) { printf(\"a\"); }\n",
+		"C/b.c":       "#include <stdlib.h>\nint f(void) { return malloc(1) != 0; }\n",
+		"C/c.c":       "#include <stdio.h>\nvoid g(int x) { printf(\"%d\", x); }\n",
+		"Java/A.java": "public class A { public static void main(String[] args) {} }\n",
+		"Java/B.java": "public class B { private final int x = 1; }\n",
+		"Java/C.java": "public interface C { void run(); }\n",
+		"Java/D.java": "import java.util.List;\npublic class D { List<String> l; }\n",
+		"README":      "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	samples, err := crossval.ReadSamples(dir)
+	require.NoError(t, err)
+	require.Len(t, samples, 7)
+	assert.Equal(t, "C", samples[0].Language)
+	assert.NotEmpty(t, samples[0].Tokens)
+
+	for _, classifierOnly := range []bool{false, true} {
+		cal := &enry.Calibrator{}
+		predictions, err := crossValidate(samples, 2, classifierOnly, cal)
+		require.NoError(t, err)
+		require.Len(t, predictions, 7)
+
+		folds := make(map[int]int)
+		for i, p := range predictions {
+			assert.Equal(t, samples[i].Path, p.path)
+			assert.Equal(t, samples[i].Language, p.expected)
+			folds[p.fold]++
+		}
+		assert.Equal(t, map[int]int{0: 4, 1: 3}, folds)
+
+		if classifierOnly {
+			assert.Equal(t, 7, cal.Len())
+		}
+	}
+
+	out := filepath.Join(dir, "out")
+	predictions, err := crossValidate(samples, 2, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, writeReport(out, newReport(2, predictions)))
+	for _, name := range []string{"report.json", "languages.csv", "confusion.csv", "pairs.csv"} {
+		assert.FileExists(t, filepath.Join(out, name))
+	}
+}
+
+func TestWriteCalibration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enry-eval")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "calibration.go")
+	require.NoError(t, writeCalibration(path, &calibration{Temperature: 0.25}))
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "var ClassifierTemperature = 0.25\n")
+
+	golden, err := ioutil.ReadFile(filepath.Join("..", "..", "data", "calibration.go"))
+	require.NoError(t, err)
+	require.NoError(t, writeCalibration(path, &calibration{Temperature: data.ClassifierTemperature}))
+	content, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(content))
+}
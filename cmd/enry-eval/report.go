@@ -0,0 +1,286 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+// noLanguage stands for enry.OtherLanguage in reports.
+const noLanguage = "(none)"
+
+type report struct {
+	Folds    int     `json:"folds"`
+	Samples  int     `json:"samples"`
+	Correct  int     `json:"correct"`
+	Accuracy float64 `json:"accuracy"`
+	// FoldAccuracy is the accuracy on the samples of each fold.
+	FoldAccuracy []float64 `json:"fold_accuracy"`
+	// MacroPrecision, MacroRecall and MacroF1 are the averages over the
+	// languages of the samples.
+	MacroPrecision float64           `json:"macro_precision"`
+	MacroRecall    float64           `json:"macro_recall"`
+	MacroF1        float64           `json:"macro_f1"`
+	Languages      []languageMetrics `json:"languages"`
+	// Confusion counts the predictions of every expected language.
+	Confusion     map[string]map[string]int `json:"confusion"`
+	ConfusedPairs []confusedPair            `json:"confused_pairs"`
+	Mistakes      []mistake                 `json:"mistakes"`
+	Calibration   *calibration              `json:"calibration,omitempty"`
+}
+
+type languageMetrics struct {
+	Language string `json:"language"`
+	// Support is the number of samples of the language.
+	Support int `json:"support"`
+	// Predicted is the number of samples detected as the language.
+	Predicted     int     `json:"predicted"`
+	TruePositives int     `json:"true_positives"`
+	Precision     float64 `json:"precision"`
+	Recall        float64 `json:"recall"`
+	F1            float64 `json:"f1"`
+}
+
+type confusedPair struct {
+	Expected  string `json:"expected"`
+	Predicted string `json:"predicted"`
+	Count     int    `json:"count"`
+}
+
+type mistake struct {
+	Path      string `json:"path"`
+	Expected  string `json:"expected"`
+	Predicted string `json:"predicted"`
+}
+
+func newReport(k int, predictions []prediction) *report {
+	r := &report{
+		Folds:        k,
+		Samples:      len(predictions),
+		FoldAccuracy: make([]float64, k),
+		Confusion:    make(map[string]map[string]int),
+	}
+
+	metrics := make(map[string]*languageMetrics)
+	get := func(language string) *languageMetrics {
+		m, ok := metrics[language]
+		if !ok {
+			m = &languageMetrics{Language: language}
+			metrics[language] = m
+		}
+		return m
+	}
+
+	foldTotal := make([]int, k)
+	foldCorrect := make([]int, k)
+	for _, p := range predictions {
+		predicted := p.predicted
+		if predicted == enry.OtherLanguage {
+			predicted = noLanguage
+		}
+
+		get(p.expected).Support++
+		get(predicted).Predicted++
+		if r.Confusion[p.expected] == nil {
+			r.Confusion[p.expected] = make(map[string]int)
+		}
+		r.Confusion[p.expected][predicted]++
+
+		foldTotal[p.fold]++
+		if predicted == p.expected {
+			get(p.expected).TruePositives++
+			foldCorrect[p.fold]++
+			r.Correct++
+		} else {
+			r.Mistakes = append(r.Mistakes, mistake{Path: p.path, Expected: p.expected, Predicted: predicted})
+		}
+	}
+
+	r.Accuracy = ratio(r.Correct, r.Samples)
+	for fold := range r.FoldAccuracy {
+		r.FoldAccuracy[fold] = ratio(foldCorrect[fold], foldTotal[fold])
+	}
+
+	var supported int
+	for _, m := range metrics {
+		m.Precision = ratio(m.TruePositives, m.Predicted)
+		m.Recall = ratio(m.TruePositives, m.Support)
+		if m.Precision+m.Recall > 0 {
+			m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall)
+		}
+
+		r.Languages = append(r.Languages, *m)
+		if m.Support > 0 {
+			supported++
+			r.MacroPrecision += m.Precision
+			r.MacroRecall += m.Recall
+			r.MacroF1 += m.F1
+		}
+	}
+	sort.Slice(r.Languages, func(i, j int) bool { return r.Languages[i].Language < r.Languages[j].Language })
+
+	if supported > 0 {
+		r.MacroPrecision /= float64(supported)
+		r.MacroRecall /= float64(supported)
+		r.MacroF1 /= float64(supported)
+	}
+
+	for expected, predictions := range r.Confusion {
+		for predicted, count := range predictions {
+			if expected != predicted {
+				r.ConfusedPairs = append(r.ConfusedPairs, confusedPair{expected, predicted, count})
+			}
+		}
+	}
+	sort.Slice(r.ConfusedPairs, func(i, j int) bool {
+		a, b := r.ConfusedPairs[i], r.ConfusedPairs[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Expected != b.Expected {
+			return a.Expected < b.Expected
+		}
+		return a.Predicted < b.Predicted
+	})
+
+	return r
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+func printSummary(w io.Writer, r *report, top int) {
+	fmt.Fprintf(w, "samples: %d, correct: %d, accuracy: %.2f%%\n", r.Samples, r.Correct, r.Accuracy*100)
+	fmt.Fprintf(w, "macro precision: %.4f, macro recall: %.4f, macro F1: %.4f\n", r.MacroPrecision, r.MacroRecall, r.MacroF1)
+	for fold, accuracy := range r.FoldAccuracy {
+		fmt.Fprintf(w, "fold %d: %.2f%%\n", fold, accuracy*100)
+	}
+
+	if c := r.Calibration; c != nil {
+		fmt.Fprintf(w, "calibration samples: %d, temperature: %.4g, log loss: %.4f, uncalibrated: %.4f\n",
+			c.Samples, c.Temperature, c.LogLoss, c.UncalibratedLogLoss)
+	}
+
+	if len(r.ConfusedPairs) == 0 {
+		return
+	}
+	if top > len(r.ConfusedPairs) {
+		top = len(r.ConfusedPairs)
+	}
+
+	fmt.Fprintln(w, "\nmost confused:")
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "expected\tpredicted\tcount")
+	for _, p := range r.ConfusedPairs[:top] {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", p.Expected, p.Predicted, p.Count)
+	}
+	tw.Flush()
+}
+
+// writeReport writes the report to dir as JSON, and its tables as CSV.
+func writeReport(dir string, r *report) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "report.json"), append(content, '\n'), 0644); err != nil {
+		return err
+	}
+
+	tables := map[string][][]string{
+		"languages.csv": languagesTable(r),
+		"confusion.csv": confusionTable(r),
+		"pairs.csv":     pairsTable(r),
+	}
+	for name, records := range tables {
+		if err := writeCSV(filepath.Join(dir, name), records); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeCSV(path string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	w.WriteAll(records)
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func languagesTable(r *report) [][]string {
+	records := [][]string{{"language", "support", "predicted", "true_positives", "precision", "recall", "f1"}}
+	for _, m := range r.Languages {
+		records = append(records, []string{
+			m.Language,
+			strconv.Itoa(m.Support),
+			strconv.Itoa(m.Predicted),
+			strconv.Itoa(m.TruePositives),
+			formatFloat(m.Precision),
+			formatFloat(m.Recall),
+			formatFloat(m.F1),
+		})
+	}
+	return records
+}
+
+// confusionTable returns the confusion matrix, with a row per expected
+// language and a column per predicted language.
+func confusionTable(r *report) [][]string {
+	languages := make([]string, len(r.Languages))
+	for i, m := range r.Languages {
+		languages[i] = m.Language
+	}
+
+	records := [][]string{append([]string{"expected\\predicted"}, languages...)}
+	for _, expected := range languages {
+		if r.Confusion[expected] == nil {
+			continue
+		}
+
+		record := []string{expected}
+		for _, predicted := range languages {
+			record = append(record, strconv.Itoa(r.Confusion[expected][predicted]))
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func pairsTable(r *report) [][]string {
+	records := [][]string{{"expected", "predicted", "count"}}
+	for _, p := range r.ConfusedPairs {
+		records = append(records, []string{p.Expected, p.Predicted, strconv.Itoa(p.Count)})
+	}
+	return records
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/data"
+	"github.com/go-enry/go-enry/v2/internal/code-generator/generator"
+)
+
+// Stages of the strategy chain a sample is resolved at.
+const (
+	stageOther      = "other"
+	stageHeuristics = "heuristics"
+	stageClassifier = "classifier"
+)
+
+// strategies are enry.DefaultStrategies, with the stage each of them stands for.
+var strategies = []struct {
+	stage    string
+	strategy enry.Strategy
+}{
+	{stageOther, enry.GetLanguagesByModeline},
+	{stageOther, enry.GetLanguagesByFilename},
+	{stageOther, enry.GetLanguagesByShebang},
+	{stageOther, enry.GetLanguagesByExtension},
+	{stageOther, enry.GetLanguagesByXML},
+	{stageOther, enry.GetLanguagesByManpage},
+	{stageHeuristics, enry.GetLanguagesByContent},
+	{stageClassifier, enry.GetLanguagesByClassifier},
+}
+
+// extensionCoverage is how well the heuristics of an extension shared by
+// several languages tell them apart.
+type extensionCoverage struct {
+	Extension string   `json:"extension"`
+	Languages []string `json:"languages"`
+	// HasHeuristics tells whether there are content heuristics for the extension.
+	HasHeuristics bool `json:"has_heuristics"`
+	// Uncovered are the languages no heuristic rule of the extension identifies.
+	Uncovered []string            `json:"uncovered"`
+	Samples   []*languageCoverage `json:"samples,omitempty"`
+}
+
+// languageCoverage counts how the samples of a language with an ambiguous
+// extension are resolved by the strategies.
+type languageCoverage struct {
+	Language string `json:"language"`
+	Samples  int    `json:"samples"`
+	// Other is the number of samples resolved before the heuristics, e.g. by shebang.
+	Other           int `json:"other"`
+	Heuristics      int `json:"heuristics"`
+	HeuristicsWrong int `json:"heuristics_wrong"`
+	Classifier      int `json:"classifier"`
+	ClassifierWrong int `json:"classifier_wrong"`
+}
+
+// classifierWrong is the number of samples of the extension the classifier got wrong.
+func (c *extensionCoverage) classifierWrong() int {
+	var wrong int
+	for _, l := range c.Samples {
+		wrong += l.ClassifierWrong
+	}
+	return wrong
+}
+
+// coverage returns the coverage of every extension shared by several
+// languages, sorted by extension.
+func coverage() []*extensionCoverage {
+	var coverages []*extensionCoverage
+	for ext, languages := range data.LanguagesByExtension {
+		if len(languages) < 2 {
+			continue
+		}
+
+		c := &extensionCoverage{Extension: ext, Languages: languages, Uncovered: []string{}}
+		covered := make(map[string]bool)
+		if heuristics, ok := data.ContentHeuristics[ext]; ok {
+			c.HasHeuristics = true
+			for _, h := range *heuristics {
+				for _, langOrAlias := range h.Languages() {
+					if lang, ok := data.LanguageByAlias(langOrAlias); ok {
+						covered[lang] = true
+					}
+				}
+			}
+		}
+
+		for _, language := range languages {
+			if !covered[language] {
+				c.Uncovered = append(c.Uncovered, language)
+			}
+		}
+		coverages = append(coverages, c)
+	}
+
+	sort.Slice(coverages, func(i, j int) bool { return coverages[i].Extension < coverages[j].Extension })
+	return coverages
+}
+
+// addSamples counts how the samples under samplesDir, laid out as Linguist
+// samples, with an ambiguous extension are resolved. Samples of a language
+// their extension isn't associated with are skipped.
+func addSamples(coverages []*extensionCoverage, samplesDir string) error {
+	byExtension := make(map[string]*extensionCoverage, len(coverages))
+	for _, c := range coverages {
+		byExtension[c.Extension] = c
+	}
+
+	langSamples, err := generator.LanguageSamples(samplesDir)
+	if err != nil {
+		return err
+	}
+
+	for langDir, paths := range langSamples {
+		expected, ok := data.LanguageByAlias(langDir)
+		if !ok {
+			expected = langDir
+		}
+
+		for _, path := range paths {
+			filename := filepath.Base(path)
+			c, ok := byExtension[strings.ToLower(filepath.Ext(filename))]
+			if !ok || !contains(c.Languages, expected) {
+				continue
+			}
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			l := c.language(expected)
+			l.Samples++
+			switch language, stage := resolve(filename, content); stage {
+			case stageHeuristics:
+				l.Heuristics++
+				if language != expected {
+					l.HeuristicsWrong++
+				}
+			case stageClassifier:
+				l.Classifier++
+				if language != expected {
+					l.ClassifierWrong++
+				}
+			default:
+				l.Other++
+			}
+		}
+	}
+
+	for _, c := range coverages {
+		sort.Slice(c.Samples, func(i, j int) bool { return c.Samples[i].Language < c.Samples[j].Language })
+	}
+
+	return nil
+}
+
+func (c *extensionCoverage) language(language string) *languageCoverage {
+	for _, l := range c.Samples {
+		if l.Language == language {
+			return l
+		}
+	}
+
+	l := &languageCoverage{Language: language}
+	c.Samples = append(c.Samples, l)
+	return l
+}
+
+// resolve runs the strategies as enry.GetLanguage does, and returns the
+// language it detects and the stage it is resolved at.
+func resolve(filename string, content []byte) (string, string) {
+	if enry.IsBinary(content) {
+		return enry.OtherLanguage, stageOther
+	}
+
+	var languages []string
+	var stage string
+	for _, s := range strategies {
+		candidates := s.strategy(filename, content, languages)
+		if len(candidates) == 0 {
+			continue
+		}
+
+		languages = candidates
+		stage = s.stage
+		if len(candidates) == 1 {
+			break
+		}
+	}
+
+	if len(languages) == 0 {
+		return enry.OtherLanguage, stageOther
+	}
+	return languages[0], stage
+}
+
+func contains(languages []string, language string) bool {
+	for _, l := range languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+// Command enry-heuristics reports how well the content heuristics cover the
+// extensions shared by several languages, and, given Linguist samples, how
+// often their samples fall through to the classifier and how often it is
+// wrong, to tell where heuristics are worth contributing upstream.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+func main() {
+	log.SetFlags(0)
+	flag.Usage = usage
+	samplesDir := flag.String("samples", "", "the Linguist samples to resolve, e.g. .linguist/samples")
+	format := flag.String("format", "text", "the output format. Available options are: text, json and csv")
+	flag.Parse()
+
+	if flag.NArg() != 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	coverages := coverage()
+	if *samplesDir != "" {
+		if err := addSamples(coverages, *samplesDir); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var err error
+	switch *format {
+	case "text":
+		err = printText(os.Stdout, coverages)
+	case "json":
+		err = printJSON(os.Stdout, coverages)
+	case "csv":
+		err = printCSV(os.Stdout, coverages)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(
+		os.Stderr,
+		`  %[1]s, reports the coverage of extensions shared by several languages by content heuristics
+  usage: %[1]s [-samples=<dir>] [-format=(text|json|csv)]
+`,
+		filepath.Base(os.Args[0]),
+	)
+	flag.PrintDefaults()
+}
+
+var header = []string{
+	"extension", "language", "heuristics", "covered", "samples",
+	"other", "by_heuristics", "heuristics_wrong", "by_classifier", "classifier_wrong",
+}
+
+// rows returns a row per language of every extension, the extensions whose
+// samples the classifier gets wrong most often first.
+func rows(coverages []*extensionCoverage) [][]string {
+	sorted := append([]*extensionCoverage(nil), coverages...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].classifierWrong() > sorted[j].classifierWrong()
+	})
+
+	var rows [][]string
+	for _, c := range sorted {
+		for _, language := range c.Languages {
+			row := []string{
+				c.Extension,
+				language,
+				strconv.FormatBool(c.HasHeuristics),
+				strconv.FormatBool(!contains(c.Uncovered, language)),
+			}
+
+			l := &languageCoverage{}
+			for _, s := range c.Samples {
+				if s.Language == language {
+					l = s
+				}
+			}
+
+			for _, n := range []int{l.Samples, l.Other, l.Heuristics, l.HeuristicsWrong, l.Classifier, l.ClassifierWrong} {
+				row = append(row, strconv.Itoa(n))
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+func printText(w io.Writer, coverages []*extensionCoverage) error {
+	var withHeuristics, uncovered int
+	for _, c := range coverages {
+		if c.HasHeuristics {
+			withHeuristics++
+		}
+		uncovered += len(c.Uncovered)
+	}
+
+	fmt.Fprintf(w, "ambiguous extensions: %d, with heuristics: %d, languages not covered by heuristics: %d\n\n",
+		len(coverages), withHeuristics, uncovered)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for _, row := range append([][]string{header}, rows(coverages)...) {
+		for i, cell := range row {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, cell)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+func printJSON(w io.Writer, coverages []*extensionCoverage) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(coverages)
+}
+
+func printCSV(w io.Writer, coverages []*extensionCoverage) error {
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	cw.WriteAll(rows(coverages))
+	return cw.Error()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrategies(t *testing.T) {
+	assert.Equal(t, len(enry.DefaultStrategies), len(strategies))
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		filename string
+		content  string
+		language string
+		stage    string
+	}{
+		{filename: "foo.go", content: "package main", language: "Go", stage: stageOther},
+		{filename: "foo.pl", content: "# vim: set ft=perl:\nprint 1;", language: "Perl", stage: stageOther},
+		{filename: "foo.pl", content: "use strict;\n", language: "Perl", stage: stageHeuristics},
+		{filename: "foo.pl", content: "print 1;\n", stage: stageClassifier},
+		{filename: "foo", content: "", language: enry.OtherLanguage, stage: stageOther},
+	}
+
+	for _, test := range tests {
+		language, stage := resolve(test.filename, []byte(test.content))
+		assert.Equal(t, test.stage, stage, test.content)
+		if test.stage != stageClassifier {
+			assert.Equal(t, test.language, language, test.content)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	coverages := coverage()
+
+	var pl *extensionCoverage
+	for i, c := range coverages {
+		assert.True(t, len(c.Languages) > 1, c.Extension)
+		if i > 0 {
+			assert.True(t, coverages[i-1].Extension < c.Extension)
+		}
+		if c.Extension == ".pl" {
+			pl = c
+		}
+	}
+
+	require.NotNil(t, pl)
+	assert.True(t, pl.HasHeuristics)
+	assert.Empty(t, pl.Uncovered)
+
+	dir, err := ioutil.TempDir("", "enry-heuristics")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"Perl/heuristics.pl": "use strict;\n",
+		"Perl/classifier.pl": "print 1;\n",
+		"Perl/modeline.pl":   "# vim: set ft=perl:\nprint 1;\n",
+		"Perl/other.pm":      "use strict;\n",
+		"Go/main.pl":         "package main\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	require.NoError(t, addSamples(coverages, dir))
+	require.Len(t, pl.Samples, 1)
+	perl := pl.Samples[0]
+	assert.Equal(t, "Perl", perl.Language)
+	assert.Equal(t, 3, perl.Samples)
+	assert.Equal(t, 1, perl.Other)
+	assert.Equal(t, 1, perl.Heuristics)
+	assert.Equal(t, 0, perl.HeuristicsWrong)
+	assert.Equal(t, 1, perl.Classifier)
+
+	var buf bytes.Buffer
+	require.NoError(t, printCSV(&buf, coverages))
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, strings.Join(header, ","), lines[0])
+	assert.Contains(t, lines, ".pl,Perl,true,true,3,1,1,0,1,"+strconv.Itoa(perl.ClassifierWrong))
+}
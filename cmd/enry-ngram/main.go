@@ -0,0 +1,297 @@
+// Command enry-ngram trains character n-gram models for enry, and compares
+// their accuracy with the default Naive Bayes classifier on Linguist samples,
+// by k-fold cross-validation.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/internal/crossval"
+	"github.com/go-enry/go-enry/v2/ngram"
+	"github.com/go-enry/go-enry/v2/tokenizer"
+)
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "train":
+		err = train(os.Args[2:])
+	case "eval":
+		err = eval(os.Args[2:], os.Stdout)
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(
+		os.Stderr,
+		`  %[1]s, trains and evaluates character n-gram models for enry
+  usage: %[1]s train [-order=N] [-buckets=N] [-epochs=N] -o <model> <samples>
+         %[1]s eval [-v] [-k=N] [-order=N] [-buckets=N] [-epochs=N] <samples>
+`,
+		os.Args[0],
+	)
+}
+
+func train(args []string) error {
+	fs := flag.NewFlagSet("train", flag.ExitOnError)
+	order := fs.Int("order", 0, "the length of the longest n-grams (0 means the default)")
+	buckets := fs.Int("buckets", 0, "the number of features n-grams are hashed to, a power of two (0 means the default)")
+	epochs := fs.Int("epochs", 0, "the number of passes over the samples (0 means the default)")
+	out := fs.String("o", "ngram.model", "the file the model is written to")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	m, err := ngram.TrainDir(fs.Arg(0), ngram.TrainOptions{
+		Order:   *order,
+		Buckets: *buckets,
+		Epochs:  *epochs,
+	})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+
+	n, err := m.WriteTo(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%d languages, %d bytes written to %s", len(m.Languages), n, *out)
+	return nil
+}
+
+func eval(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("eval", flag.ExitOnError)
+	k := fs.Int("k", 5, "the number of folds")
+	order := fs.Int("order", 0, "the length of the longest n-grams (0 means the default)")
+	buckets := fs.Int("buckets", 0, "the number of features n-grams are hashed to, a power of two (0 means the default)")
+	epochs := fs.Int("epochs", 0, "the number of passes over the samples (0 means the default)")
+	verbose := fs.Bool("v", false, "list the misclassified samples")
+	fs.Parse(args)
+	if fs.NArg() != 1 || *k < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	samples, err := crossval.ReadSamples(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	opts := ngram.TrainOptions{Order: *order, Buckets: *buckets, Epochs: *epochs}
+	classifiers := []classifier{
+		{name: "naive-bayes", train: trainNaiveBayes},
+		{name: "ngram", train: func(samples []*crossval.Sample) (enry.Classifier, error) {
+			return trainNgram(samples, opts)
+		}},
+	}
+
+	results, err := evaluate(samples, *k, classifiers)
+	if err != nil {
+		return err
+	}
+
+	printResults(w, results)
+	if *verbose {
+		printMistakes(w, results)
+	}
+	return nil
+}
+
+// classifier is a classifier under evaluation, trained on the samples of
+// every fold but one.
+type classifier struct {
+	name  string
+	train func(samples []*crossval.Sample) (enry.Classifier, error)
+}
+
+func trainNaiveBayes(samples []*crossval.Sample) (enry.Classifier, error) {
+	return enry.NewNaiveBayesWithModel(crossval.TrainNaiveBayes(samples), tokenizer.Options{}), nil
+}
+
+func trainNgram(samples []*crossval.Sample, opts ngram.TrainOptions) (enry.Classifier, error) {
+	ngramSamples := make([]ngram.Sample, len(samples))
+	for i, s := range samples {
+		ngramSamples[i] = ngram.Sample{Language: s.Language, Filename: s.Path, Content: s.Content}
+	}
+	return ngram.Train(ngramSamples, opts)
+}
+
+// result is the accuracy of a classifier for a set of candidates.
+type result struct {
+	classifier string
+	candidates string
+	total      int
+	correct    int
+	mistakes   []mistake
+}
+
+type mistake struct {
+	filename string
+	expected string
+	got      string
+}
+
+// evaluate classifies every sample with every classifier, trained on the
+// samples of the other folds, twice: among the languages of its extension or
+// filename, the case the classifier is used for in the strategy chain, when
+// there are several of them, and among all the languages of the samples.
+func evaluate(samples []*crossval.Sample, k int, classifiers []classifier) ([]*result, error) {
+	var all []string
+	seen := make(map[string]bool)
+	for _, sample := range samples {
+		if !seen[sample.Language] {
+			seen[sample.Language] = true
+			all = append(all, sample.Language)
+		}
+	}
+	sort.Strings(all)
+
+	kinds := []string{"ambiguous", "all"}
+	candidates := make(map[string][][]string, len(kinds))
+	for _, kind := range kinds {
+		candidates[kind] = make([][]string, len(samples))
+		for i, sample := range samples {
+			candidates[kind][i] = all
+			if kind == "ambiguous" {
+				candidates[kind][i] = ambiguousCandidates(filepath.Base(sample.Path), sample.Content)
+			}
+		}
+	}
+
+	// the first language detected for every sample, by classifier and kind
+	// of candidates
+	got := make(map[string][][]string, len(kinds))
+	for _, kind := range kinds {
+		got[kind] = make([][]string, len(classifiers))
+		for c := range classifiers {
+			got[kind][c] = make([]string, len(samples))
+		}
+	}
+
+	err := crossval.Run(samples, k, func(_ int, train []*crossval.Sample, test []int) error {
+		for c, cl := range classifiers {
+			trained, err := cl.train(train)
+			if err != nil {
+				return err
+			}
+
+			classify := enry.ClassifierStrategy(trained)
+			crossval.Parallel(len(test), func(j int) {
+				i := test[j]
+				filename := filepath.Base(samples[i].Path)
+				for _, kind := range kinds {
+					if languages := classify(filename, samples[i].Content, candidates[kind][i]); len(languages) > 0 {
+						got[kind][c][i] = languages[0]
+					}
+				}
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*result
+	for _, kind := range kinds {
+		for c, cl := range classifiers {
+			r := &result{classifier: cl.name, candidates: kind}
+			for i, sample := range samples {
+				if kind == "ambiguous" && !contains(candidates[kind][i], sample.Language) {
+					continue
+				}
+
+				r.total++
+				if got[kind][c][i] == sample.Language {
+					r.correct++
+					continue
+				}
+
+				r.mistakes = append(r.mistakes, mistake{
+					filename: sample.Path,
+					expected: sample.Language,
+					got:      got[kind][c][i],
+				})
+			}
+			results = append(results, r)
+		}
+	}
+
+	return results, nil
+}
+
+// ambiguousCandidates returns the languages of the filename, or of its
+// extension, when there are several of them.
+func ambiguousCandidates(filename string, content []byte) []string {
+	candidates := enry.GetLanguagesByFilename(filename, content, nil)
+	if len(candidates) == 0 {
+		candidates = enry.GetLanguagesByExtension(filename, content, nil)
+	}
+	if len(candidates) < 2 {
+		return nil
+	}
+	return candidates
+}
+
+func contains(languages []string, language string) bool {
+	for _, l := range languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+func printResults(w io.Writer, results []*result) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "classifier\tcandidates\tsamples\tcorrect\taccuracy")
+	for _, r := range results {
+		var accuracy float64
+		if r.total > 0 {
+			accuracy = float64(r.correct) / float64(r.total) * 100
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%.2f%%\n", r.classifier, r.candidates, r.total, r.correct, accuracy)
+	}
+	tw.Flush()
+}
+
+func printMistakes(w io.Writer, results []*result) {
+	for _, r := range results {
+		fmt.Fprintf(w, "\n%s, %s candidates:\n", r.classifier, r.candidates)
+		for _, m := range r.mistakes {
+			fmt.Fprintf(w, "%s\texpected: %s\tgot: %s\n", m.filename, m.expected, m.got)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/internal/crossval"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorizer is a Classifier knowing the language of the samples it was
+// trained on only, and guessing the first candidate for any other content.
+type memorizer map[string]string
+
+func (m memorizer) Probabilities(content []byte, candidates []string) []float64 {
+	probabilities := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		if m[string(content)] == candidate {
+			probabilities[i] = 1
+			return probabilities
+		}
+	}
+	probabilities[0] = 1
+	return probabilities
+}
+
+func TestEvaluate(t *testing.T) {
+	sample := func(dir, path, content string) *crossval.Sample {
+		return &crossval.Sample{Dir: dir, Language: dir, Path: "samples/" + dir + "/" + path, Content: []byte(content)}
+	}
+	samples := []*crossval.Sample{
+		sample("C", "a.h", "int a;"),
+		sample("C", "b.h", "int b;"),
+		sample("C++", "a.h", "class a;"),
+		sample("C++", "b.h", "class b;"),
+		sample("Go", "a.go", "package a"),
+		sample("Go", "b.go", "package b"),
+	}
+
+	var trained [][]string
+	memorize := func(samples []*crossval.Sample) (enry.Classifier, error) {
+		var paths []string
+		m := make(memorizer)
+		for _, s := range samples {
+			paths = append(paths, s.Path)
+			m[string(s.Content)] = s.Language
+		}
+		trained = append(trained, paths)
+		return m, nil
+	}
+
+	results, err := evaluate(samples, 2, []classifier{{name: "memorizer", train: memorize}})
+	require.NoError(t, err)
+
+	// every fold is classified by a model trained on the other one only, so
+	// the memorizer never knows the samples it classifies
+	assert.Equal(t, [][]string{
+		{"samples/C/b.h", "samples/C++/b.h", "samples/Go/b.go"},
+		{"samples/C/a.h", "samples/C++/a.h", "samples/Go/a.go"},
+	}, trained)
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, "ambiguous", results[0].candidates)
+	assert.Equal(t, 4, results[0].total)
+	assert.Equal(t, 2, results[0].correct)
+	assert.Equal(t, []mistake{
+		{filename: "samples/C++/a.h", expected: "C++", got: "C"},
+		{filename: "samples/C++/b.h", expected: "C++", got: "C"},
+	}, results[0].mistakes)
+	assert.Equal(t, "all", results[1].candidates)
+	assert.Equal(t, 6, results[1].total)
+	assert.Equal(t, 2, results[1].correct)
+
+	var buf bytes.Buffer
+	printResults(&buf, results)
+	assert.Equal(t, `classifier  candidates  samples  correct  accuracy
+memorizer   ambiguous   4        2        50.00%
+memorizer   all         6        2        33.33%
+`, buf.String())
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+const (
+	// otherLanguage is the bucket of the languages under the threshold of a badge.
+	otherLanguage = "Other"
+	otherColor    = "#ededed"
+
+	badgeBarHeight = 8
+	// badgeLegendTop is the center of the first row of the legend, and
+	// badgeLegendRow the height of the rows.
+	badgeLegendTop = 24
+	badgeLegendRow = 20
+	// badgeCharWidth is the estimated width of a character of the legend,
+	// which can't be measured without the font, so that the layout only
+	// depends on the languages.
+	badgeCharWidth = 7
+	badgeFont      = "-apple-system,BlinkMacSystemFont,Segoe UI,Helvetica,Arial,sans-serif"
+)
+
+// badgeLanguage is a segment of the bar of a badge, and an entry of its legend.
+type badgeLanguage struct {
+	name       string
+	color      string
+	percentage float64
+}
+
+func (l badgeLanguage) label() string {
+	return fmt.Sprintf("%s %.1f%%", l.name, l.percentage)
+}
+
+// badgeLanguages returns the languages of the rows with a share of at least
+// threshold percent, as languageRows sorts them, then the others together
+// as Other, if any.
+func badgeLanguages(rows []*languageRow, threshold float64) []badgeLanguage {
+	var languages []badgeLanguage
+	other := badgeLanguage{name: otherLanguage, color: otherColor}
+	for _, row := range rows {
+		if row.percentage == 0 {
+			continue
+		}
+
+		if row.percentage < threshold {
+			other.percentage += row.percentage
+			continue
+		}
+
+		languages = append(languages, badgeLanguage{
+			name:       row.language,
+			color:      enry.GetColor(row.language),
+			percentage: row.percentage,
+		})
+	}
+
+	if other.percentage > 0 {
+		languages = append(languages, other)
+	}
+	return languages
+}
+
+// writeBadge writes an SVG of the given width with a bar of the languages,
+// stacked by share like on GitHub, and a legend wrapped under it.
+func writeBadge(w io.Writer, languages []badgeLanguage, width int) error {
+	var legend bytes.Buffer
+	x, rows := 0, 0
+	for _, l := range languages {
+		label := l.label()
+		entryWidth := 12 + utf8.RuneCountInString(label)*badgeCharWidth
+		if rows == 0 || (x > 0 && x+entryWidth > width) {
+			x = 0
+			rows++
+		}
+
+		cy := badgeLegendTop + (rows-1)*badgeLegendRow
+		fmt.Fprintf(&legend, "    <circle cx=\"%d\" cy=\"%d\" r=\"4\" fill=\"%s\"/>\n", x+4, cy, escapeXML(l.color))
+		fmt.Fprintf(&legend, "    <text x=\"%d\" y=\"%d\">%s <tspan fill=\"#656d76\">%.1f%%</tspan></text>\n",
+			x+12, cy+4, escapeXML(l.name), l.percentage)
+		x += entryWidth + 16
+	}
+
+	height := badgeBarHeight
+	if rows > 0 {
+		height = badgeLegendTop + (rows-1)*badgeLegendRow + 8
+	}
+
+	labels := make([]string, 0, len(languages))
+	for _, l := range languages {
+		labels = append(labels, l.label())
+	}
+	if len(labels) == 0 {
+		labels = append(labels, "none")
+	}
+	title := escapeXML("Languages: " + strings.Join(labels, ", "))
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %[1]d %[2]d\" role=\"img\" aria-label=\"%s\">\n", width, height, title)
+	fmt.Fprintf(&b, "  <title>%s</title>\n", title)
+	fmt.Fprintf(&b, "  <clipPath id=\"bar\">\n    <rect width=\"%d\" height=\"%d\" rx=\"%d\"/>\n  </clipPath>\n", width, badgeBarHeight, badgeBarHeight/2)
+	b.WriteString("  <g clip-path=\"url(#bar)\">\n")
+	if len(languages) == 0 {
+		fmt.Fprintf(&b, "    <rect width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", width, badgeBarHeight, otherColor)
+	}
+
+	// the segments are placed by their cumulated share, so that rounding
+	// leaves no gap between them
+	var total float64
+	for _, l := range languages {
+		start := float64(width) * total / 100
+		total += l.percentage
+		end := float64(width) * total / 100
+		fmt.Fprintf(&b, "    <rect x=\"%.2f\" width=\"%.2f\" height=\"%d\" fill=\"%s\"/>\n", start, end-start, badgeBarHeight, escapeXML(l.color))
+	}
+	b.WriteString("  </g>\n")
+
+	if legend.Len() > 0 {
+		fmt.Fprintf(&b, "  <g font-family=\"%s\" font-size=\"12\" fill=\"#1f2328\">\n", badgeFont)
+		legend.WriteTo(&b)
+		b.WriteString("  </g>\n")
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := b.WriteTo(w)
+	return err
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// badge runs enry badge, printing the badge of the languages of a directory.
+func badge(args []string) error {
+	flags := flag.NewFlagSet("badge", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "  usage: %s badge [<flags>] <path>\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	width := flags.Int("width", 320, "the width of the badge in pixels")
+	threshold := flags.Float64("threshold", 1, "Group the languages under N percent of the bytes as Other")
+	filters := newWalkFlags(flags)
+	flags.Parse(args)
+
+	root, err := filepath.Abs(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	opts, limit, err := tokenizerOptions("head", *filters.limit, 0, false)
+	if err != nil {
+		return err
+	}
+
+	detector, err := enry.NewDetector(enry.WithTokenizer(opts))
+	if err != nil {
+		return err
+	}
+
+	walk := filters.options()
+	walk.limit = limit
+	results, _, err := detectDir(detector, root, walk)
+	if err != nil {
+		return err
+	}
+
+	rows := languageRows(results, "byte")
+	return writeBadge(os.Stdout, badgeLanguages(rows, *threshold), *width)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGold = flag.Bool("update_gold", false, "Update golden test files")
+
+func TestBadge(t *testing.T) {
+	results, _ := detectTestRepo(t, walkOptions{})
+	repo := languageRows(results, "byte")
+
+	many := []*languageRow{
+		{language: "Go", percentage: 41.5},
+		{language: "TypeScript", percentage: 20},
+		{language: "C++", percentage: 12.25},
+		{language: "F#", percentage: 9},
+		{language: "Shell", percentage: 8},
+		{language: "Ren'Py", percentage: 4.5},
+		{language: "Makefile", percentage: 3},
+		{language: "Dockerfile", percentage: 1.75},
+	}
+
+	tests := []struct {
+		golden    string
+		rows      []*languageRow
+		threshold float64
+		width     int
+	}{
+		{golden: "repo.svg", rows: repo, threshold: 1, width: 320},
+		{golden: "other.svg", rows: many, threshold: 5, width: 320},
+		{golden: "narrow.svg", rows: many, threshold: 0, width: 160},
+		{golden: "empty.svg", rows: nil, threshold: 1, width: 320},
+	}
+
+	for _, test := range tests {
+		t.Run(test.golden, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeBadge(&buf, badgeLanguages(test.rows, test.threshold), test.width))
+
+			golden := filepath.Join("testdata", "badge", test.golden)
+			if *updateGold {
+				require.NoError(t, ioutil.WriteFile(golden, buf.Bytes(), 0644))
+			}
+
+			expected, err := ioutil.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), buf.String())
+		})
+	}
+}
+
+func TestBadgeLanguages(t *testing.T) {
+	rows := []*languageRow{
+		{language: "Go", percentage: 90},
+		{language: "Shell", percentage: 6},
+		{language: "Makefile", percentage: 4},
+		{language: "HTML", percentage: 0},
+	}
+
+	languages := badgeLanguages(rows, 5)
+	require.Len(t, languages, 3)
+	assert.Equal(t, badgeLanguage{name: "Go", color: enry.GetColor("Go"), percentage: 90}, languages[0])
+	assert.Equal(t, "Shell", languages[1].name)
+	assert.Equal(t, badgeLanguage{name: otherLanguage, color: otherColor, percentage: 4}, languages[2])
+}
+
+func TestWalkFlags(t *testing.T) {
+	flags := flag.NewFlagSet("badge", flag.ContinueOnError)
+	filters := newWalkFlags(flags)
+	require.NoError(t, flags.Parse([]string{"-exclude", "/web", "-exclude", "*.sh", "-include-vendor", "-j", "2", "-limit", "1", "repo"}))
+	assert.Equal(t, []string{"repo"}, flags.Args())
+
+	opts := filters.options()
+	assert.Len(t, opts.exclude, 2)
+	assert.True(t, opts.includeVendor)
+	assert.False(t, opts.gitignore)
+	assert.Equal(t, 2, opts.jobs)
+	assert.Equal(t, 1, *filters.limit)
+
+	results, _ := detectTestRepo(t, opts)
+	var paths []string
+	for _, r := range results {
+		paths = append(paths, r.Path)
+	}
+	assert.Equal(t, []string{"lib/add.go", "main.go", "vendor/dep/dep.go"}, paths)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// dirSummary is the language breakdown of the files under a directory.
+type dirSummary struct {
+	Dir       string        `json:"dir"`
+	Files     int           `json:"files"`
+	Bytes     int64         `json:"bytes"`
+	Lines     int           `json:"lines"`
+	Languages []dirLanguage `json:"languages"`
+}
+
+type dirLanguage struct {
+	Language   string  `json:"language"`
+	Files      int     `json:"files"`
+	Bytes      int64   `json:"bytes"`
+	Lines      int     `json:"lines"`
+	Percentage float64 `json:"percentage"`
+	// GeneratedBytes counts the generated files, whether left out or not.
+	GeneratedBytes int64 `json:"generated_bytes"`
+}
+
+// dirPrefix returns the directory of a slash-separated path, cut to its
+// first depth elements, or "." for the files at the root.
+func dirPrefix(file string, depth int) string {
+	dir := path.Dir(file)
+	if dir == "." {
+		return dir
+	}
+
+	elems := strings.Split(dir, "/")
+	if len(elems) > depth {
+		elems = elems[:depth]
+	}
+	return strings.Join(elems, "/")
+}
+
+// dirBreakdown returns the language breakdown of the records grouped by the
+// directories up to depth, sorted by directory. The groups are exclusive: a
+// record is only counted in the directory of dirPrefix, not in its parents.
+// Percentages are computed within every directory according to mode.
+func dirBreakdown(records []fileRecord, depth int, mode string) []*dirSummary {
+	byDir := make(map[string][]fileRecord)
+	for _, r := range records {
+		dir := dirPrefix(r.Path, depth)
+		byDir[dir] = append(byDir[dir], r)
+	}
+
+	summaries := make([]*dirSummary, 0, len(byDir))
+	for dir, records := range byDir {
+		s := &dirSummary{Dir: dir, Languages: []dirLanguage{}}
+		for _, row := range languageRows(records, mode) {
+			s.Files += row.files
+			s.Bytes += row.bytes
+			s.Lines += row.lines
+			s.Languages = append(s.Languages, dirLanguage{
+				Language:       row.language,
+				Files:          row.files,
+				Bytes:          row.bytes,
+				Lines:          row.lines,
+				Percentage:     row.percentage,
+				GeneratedBytes: row.generatedBytes,
+			})
+		}
+		summaries = append(summaries, s)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Dir < summaries[j].Dir })
+	return summaries
+}
+
+// printByDir prints the breakdown by directory of the records in the given
+// format: text, json, one JSON object per directory for json-lines, or a
+// row per language of every directory for the tabular formats.
+func printByDir(w io.Writer, format string, records []fileRecord, depth int, mode string) error {
+	summaries := dirBreakdown(records, depth, mode)
+	switch format {
+	case "json":
+		return json.NewEncoder(w).Encode(summaries)
+	case "json-lines":
+		e := json.NewEncoder(w)
+		for _, s := range summaries {
+			if err := e.Encode(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "text":
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "dir\tfiles\tlanguages")
+		for _, s := range summaries {
+			var languages []string
+			for _, l := range s.Languages {
+				if l.Files == 0 {
+					continue
+				}
+				languages = append(languages, fmt.Sprintf("%s %.2f%%", l.Language, l.Percentage))
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", s.Dir, s.Files, strings.Join(languages, ", "))
+		}
+		return tw.Flush()
+	}
+
+	header := []string{"dir", "language", "files", "bytes", "lines", "percentage", "generated_bytes"}
+	var rows [][]string
+	for _, s := range summaries {
+		for _, l := range s.Languages {
+			rows = append(rows, []string{
+				s.Dir,
+				l.Language,
+				strconv.Itoa(l.Files),
+				strconv.FormatInt(l.Bytes, 10),
+				strconv.Itoa(l.Lines),
+				fmt.Sprintf("%.2f", l.Percentage),
+				strconv.FormatInt(l.GeneratedBytes, 10),
+			})
+		}
+	}
+
+	switch format {
+	case "markdown":
+		for _, row := range rows {
+			withPercentSign(row, 5)
+		}
+		return writeMarkdown(w, header, rows, 2)
+	case "tsv":
+		return writeCSV(w, '\t', header, rows)
+	default:
+		return writeCSV(w, ',', header, rows)
+	}
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/data"
+)
+
+// formats are the values of -format, besides text.
+var formats = map[string]func(w io.Writer, records []fileRecord, mode string) error{
+	"csv":        printCSV(','),
+	"tsv":        printCSV('\t'),
+	"markdown":   printMarkdown,
+	"json":       printLanguagesJSON(false),
+	"json-lines": printJSONLines,
+}
+
+func validFormat(format string) bool {
+	_, ok := formats[format]
+	return ok || format == "text"
+}
+
+// fileRecord is everything reported about a file by the json-lines format.
+type fileRecord struct {
+	Path      string   `json:"path"`
+	Language  string   `json:"language"`
+	Languages []string `json:"languages"`
+	Type      string   `json:"type"`
+	Group     string   `json:"group,omitempty"`
+	Color     string   `json:"color,omitempty"`
+	MimeType  string   `json:"mime_type"`
+	Bytes     int64    `json:"bytes"`
+	Lines     int      `json:"lines"`
+	Sloc      int      `json:"sloc"`
+	Test      bool     `json:"test"`
+	Generated bool     `json:"generated"`
+	// skipped is set for the generated files left out of the analysis,
+	// which only count as generated bytes.
+	skipped bool
+	// strategy is the strategy that resolved the language, reported by -list.
+	strategy string
+}
+
+// newFileRecord returns the record of the file of a Result, given its stats.
+func newFileRecord(r enry.Result, stats fileStats) fileRecord {
+	return fileRecord{
+		Path:      filepath.ToSlash(r.Path),
+		Language:  r.Language,
+		Languages: r.Languages,
+		Type:      data.Type(enry.GetLanguageType(r.Language)).String(),
+		Group:     enry.GetLanguageGroup(r.Language),
+		Color:     enry.GetColor(r.Language),
+		MimeType:  enry.GetMIMEType(r.Path, r.Language),
+		Bytes:     stats.bytes,
+		Lines:     stats.lines,
+		Sloc:      stats.nonBlank,
+		Test:      enry.IsTest(r.Path),
+		Generated: stats.generated,
+		strategy:  r.Strategy,
+	}
+}
+
+// contentRecord returns the record of a Result, given the content of its file.
+func contentRecord(r enry.Result, content []byte) fileRecord {
+	lines, sloc := getLines(r.Path, content)
+	return newFileRecord(r, fileStats{
+		bytes:     int64(len(content)),
+		lines:     lines,
+		nonBlank:  sloc,
+		generated: enry.IsGenerated(filepath.ToSlash(r.Path), content),
+	})
+}
+
+// allFileRecords returns the records of the results and of the generated
+// files left out of them, returned by detectDir.
+func allFileRecords(results, generated []fileRecord) []fileRecord {
+	records := append([]fileRecord(nil), results...)
+	for _, r := range generated {
+		r.skipped = true
+		records = append(records, r)
+	}
+	return records
+}
+
+// printFormat prints the records in the given format, with the bytes of the
+// generated files left out of them.
+func printFormat(w io.Writer, format string, results, generated []fileRecord, mode string) error {
+	return formats[format](w, allFileRecords(results, generated), mode)
+}
+
+// languageRow is the summary of a language in the tabular formats.
+type languageRow struct {
+	language string
+	files    int
+	bytes    int64
+	lines    int
+	// percentage is the share of the language in files, lines or bytes, depending on the mode.
+	percentage float64
+	// generatedBytes counts the generated files, whether left out or not.
+	generatedBytes int64
+	// paths are the files counted, in the order of the records.
+	paths []string
+}
+
+var tableHeader = []string{"language", "type", "group", "color", "files", "bytes", "lines", "percentage", "generated_bytes"}
+
+// percentageColumn is the index of the percentage in the rows of tableHeader.
+const percentageColumn = 7
+
+// languageRows returns the summary of every language of the records,
+// from the largest share to the smallest according to mode.
+func languageRows(records []fileRecord, mode string) []*languageRow {
+	byLanguage := make(map[string]*languageRow)
+	var rows []*languageRow
+	for _, r := range records {
+		row, ok := byLanguage[r.Language]
+		if !ok {
+			row = &languageRow{language: r.Language}
+			byLanguage[r.Language] = row
+			rows = append(rows, row)
+		}
+
+		if r.Generated {
+			row.generatedBytes += r.Bytes
+		}
+		if r.skipped {
+			continue
+		}
+
+		row.files++
+		row.bytes += r.Bytes
+		row.lines += r.Lines
+		row.paths = append(row.paths, r.Path)
+	}
+
+	amount := func(row *languageRow) float64 {
+		switch mode {
+		case "file":
+			return float64(row.files)
+		case "line":
+			return float64(row.lines)
+		default:
+			return float64(row.bytes)
+		}
+	}
+
+	var total float64
+	for _, row := range rows {
+		total += amount(row)
+	}
+	for _, row := range rows {
+		if total > 0 {
+			row.percentage = amount(row) / total * 100
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].percentage != rows[j].percentage {
+			return rows[i].percentage > rows[j].percentage
+		}
+		return rows[i].language < rows[j].language
+	})
+	return rows
+}
+
+func (row *languageRow) cells() []string {
+	return []string{
+		row.language,
+		data.Type(enry.GetLanguageType(row.language)).String(),
+		enry.GetLanguageGroup(row.language),
+		enry.GetColor(row.language),
+		strconv.Itoa(row.files),
+		strconv.FormatInt(row.bytes, 10),
+		strconv.Itoa(row.lines),
+		fmt.Sprintf("%.2f", row.percentage),
+		strconv.FormatInt(row.generatedBytes, 10),
+	}
+}
+
+func printCSV(comma rune) func(w io.Writer, records []fileRecord, mode string) error {
+	return func(w io.Writer, records []fileRecord, mode string) error {
+		var rows [][]string
+		for _, row := range languageRows(records, mode) {
+			rows = append(rows, row.cells())
+		}
+		return writeCSV(w, comma, tableHeader, rows)
+	}
+}
+
+func printMarkdown(w io.Writer, records []fileRecord, mode string) error {
+	var rows [][]string
+	for _, row := range languageRows(records, mode) {
+		rows = append(rows, withPercentSign(row.cells(), percentageColumn))
+	}
+	return writeMarkdown(w, tableHeader, rows, 4)
+}
+
+// withPercentSign appends % to the cell of a row with the percentage.
+func withPercentSign(cells []string, percentage int) []string {
+	cells[percentage] += "%"
+	return cells
+}
+
+func writeCSV(w io.Writer, comma rune, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	cw.Write(header)
+	cw.WriteAll(rows)
+	return cw.Error()
+}
+
+// writeMarkdown writes a Markdown table, with the columns from the numeric
+// one on aligned to the right.
+func writeMarkdown(w io.Writer, header []string, rows [][]string, numeric int) error {
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + strings.Replace(cell, "|", "\\|", -1) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(header)
+	b.WriteString("|")
+	for i := range header {
+		if i < numeric {
+			b.WriteString(" --- |")
+		} else {
+			b.WriteString(" ---: |")
+		}
+	}
+	b.WriteString("\n")
+
+	for _, row := range rows {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// languageSummary is the summary of a language in the json format.
+type languageSummary struct {
+	Language       string  `json:"language"`
+	Type           string  `json:"type"`
+	Group          string  `json:"group,omitempty"`
+	Color          string  `json:"color,omitempty"`
+	Files          int     `json:"files"`
+	Bytes          int64   `json:"bytes"`
+	Lines          int     `json:"lines"`
+	Percentage     float64 `json:"percentage"`
+	GeneratedBytes int64   `json:"generated_bytes"`
+	// Paths are the files of the language, given -breakdown.
+	Paths []string `json:"paths,omitempty"`
+}
+
+// printLanguagesJSON returns the printer of the json format, listing the
+// files of every language if breakdown is set, as -json -breakdown does.
+func printLanguagesJSON(breakdown bool) func(w io.Writer, records []fileRecord, mode string) error {
+	return func(w io.Writer, records []fileRecord, mode string) error {
+		summaries := []languageSummary{}
+		for _, row := range languageRows(records, mode) {
+			s := languageSummary{
+				Language:       row.language,
+				Type:           data.Type(enry.GetLanguageType(row.language)).String(),
+				Group:          enry.GetLanguageGroup(row.language),
+				Color:          enry.GetColor(row.language),
+				Files:          row.files,
+				Bytes:          row.bytes,
+				Lines:          row.lines,
+				Percentage:     row.percentage,
+				GeneratedBytes: row.generatedBytes,
+			}
+			if breakdown {
+				s.Paths = row.paths
+			}
+			summaries = append(summaries, s)
+		}
+
+		return json.NewEncoder(w).Encode(summaries)
+	}
+}
+
+func printJSONLines(w io.Writer, records []fileRecord, _ string) error {
+	e := json.NewEncoder(w)
+	for _, r := range records {
+		if r.skipped {
+			continue
+		}
+
+		if err := e.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
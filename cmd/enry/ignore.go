@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignorePattern is a pattern of a .gitignore file, also used by -exclude and
+// -include. See https://git-scm.com/docs/gitignore#_pattern_format.
+type ignorePattern struct {
+	re *regexp.Regexp
+	// negate re-includes the paths matched, for patterns starting with !.
+	negate bool
+	// dirOnly only matches directories, for patterns ending with /.
+	dirOnly bool
+}
+
+// parseIgnorePattern parses a line of a .gitignore file. It returns false for
+// blank lines and comments.
+func parseIgnorePattern(line string) (ignorePattern, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignorePattern{}, false
+	}
+
+	var p ignorePattern
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	if line == "" {
+		return ignorePattern{}, false
+	}
+
+	// patterns with a slash are relative to the directory of the .gitignore,
+	// the others match at any depth
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	var re strings.Builder
+	re.WriteString("^")
+	if !anchored {
+		re.WriteString("(?:.*/)?")
+	}
+	re.WriteString(globToRegexp(line))
+	re.WriteString("$")
+
+	compiled, err := regexp.Compile(re.String())
+	if err != nil {
+		return ignorePattern{}, false
+	}
+
+	p.re = compiled
+	return p, true
+}
+
+// globToRegexp translates the wildcards of a .gitignore pattern to a regexp.
+func globToRegexp(glob string) string {
+	var re strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/") && (i == 0 || glob[i-1] == '/'):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case c == '*':
+			re.WriteString("[^/]*")
+		case c == '?':
+			re.WriteString("[^/]")
+		case c == '\\' && i+1 < len(glob):
+			i++
+			re.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				re.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			re.WriteString("[" + strings.Replace(class, "\\", "\\\\", -1) + "]")
+			i += end + 1
+		default:
+			re.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return re.String()
+}
+
+// match tells whether the pattern matches a slash-separated path, relative
+// to the directory the pattern applies to.
+func (p ignorePattern) match(path string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	return p.re.MatchString(path)
+}
+
+// parseIgnorePatterns parses the given patterns, e.g. the values of -exclude.
+func parseIgnorePatterns(lines []string) []ignorePattern {
+	var patterns []ignorePattern
+	for _, line := range lines {
+		if p, ok := parseIgnorePattern(line); ok {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// matchPatterns tells whether a slash-separated path matches the patterns:
+// the last one matching it wins, so that negated patterns re-include paths.
+// matched is false if none matches.
+func matchPatterns(patterns []ignorePattern, path string, isDir bool) (ignored, matched bool) {
+	for _, p := range patterns {
+		if p.match(path, isDir) {
+			ignored, matched = !p.negate, true
+		}
+	}
+	return ignored, matched
+}
+
+// ignoreFiles are the files ignore patterns are read from in every directory,
+// the later ones taking precedence, as ripgrep does.
+var ignoreFiles = []string{".gitignore", ".ignore"}
+
+// ignorer tells whether paths are ignored by the .gitignore and .ignore files
+// of their directories, and .git/info/exclude, as git does.
+type ignorer struct {
+	root string
+	// patterns are the ones of every directory read, by slash-separated path
+	// relative to root, "." for root.
+	patterns map[string][]ignorePattern
+}
+
+func newIgnorer(root string) (*ignorer, error) {
+	ig := &ignorer{root: root, patterns: make(map[string][]ignorePattern)}
+	exclude, err := readIgnoreFile(filepath.Join(root, ".git", "info", "exclude"))
+	if err != nil {
+		return nil, err
+	}
+
+	ig.patterns["."] = exclude
+	return ig, ig.load(".")
+}
+
+// load reads the ignore files of a directory, given as a slash-separated
+// path relative to root. It must be called for every directory before the
+// paths in it are checked.
+func (ig *ignorer) load(dir string) error {
+	for _, name := range ignoreFiles {
+		patterns, err := readIgnoreFile(filepath.Join(ig.root, filepath.FromSlash(dir), name))
+		if err != nil {
+			return err
+		}
+		ig.patterns[dir] = append(ig.patterns[dir], patterns...)
+	}
+	return nil
+}
+
+// loaded tells whether the ignore files of a directory have been read.
+func (ig *ignorer) loaded(dir string) bool {
+	_, ok := ig.patterns[dir]
+	return ok
+}
+
+// ignored tells whether a slash-separated path relative to root is ignored.
+// The patterns of the deeper directories take precedence.
+func (ig *ignorer) ignored(file string, isDir bool) bool {
+	dir := path.Dir(file)
+	for {
+		if patterns, ok := ig.patterns[dir]; ok {
+			rel := file
+			if dir != "." {
+				rel = strings.TrimPrefix(file, dir+"/")
+			}
+
+			if i, matched := matchPatterns(patterns, rel, isDir); matched {
+				return i
+			}
+		}
+
+		if dir == "." {
+			return false
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// readIgnoreFile returns the patterns of an ignore file, none if it doesn't exist.
+func readIgnoreFile(path string) ([]ignorePattern, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return parseIgnorePatterns(lines), nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnorePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		matched bool
+	}{
+		{pattern: "*.o", path: "foo.o", matched: true},
+		{pattern: "*.o", path: "a/b/foo.o", matched: true},
+		{pattern: "*.o", path: "foo.c"},
+		{pattern: "build/", path: "build", isDir: true, matched: true},
+		{pattern: "build/", path: "a/build", isDir: true, matched: true},
+		{pattern: "build/", path: "build"},
+		{pattern: "/build", path: "build", isDir: true, matched: true},
+		{pattern: "/build", path: "a/build", isDir: true},
+		{pattern: "doc/*.txt", path: "doc/notes.txt", matched: true},
+		{pattern: "doc/*.txt", path: "doc/server/arch.txt"},
+		{pattern: "doc/*.txt", path: "a/doc/notes.txt"},
+		{pattern: "**/foo", path: "foo", matched: true},
+		{pattern: "**/foo", path: "a/b/foo", matched: true},
+		{pattern: "abc/**", path: "abc/x/y", matched: true},
+		{pattern: "abc/**", path: "abc", isDir: true},
+		{pattern: "a/**/b", path: "a/b", matched: true},
+		{pattern: "a/**/b", path: "a/x/y/b", matched: true},
+		{pattern: "fo?.go", path: "foo.go", matched: true},
+		{pattern: "fo?.go", path: "fo/.go"},
+		{pattern: "[a-c].go", path: "b.go", matched: true},
+		{pattern: "[!a-c].go", path: "b.go"},
+		{pattern: "\\#file", path: "#file", matched: true},
+		{pattern: "trailing   ", path: "trailing", matched: true},
+	}
+
+	for _, test := range tests {
+		p, ok := parseIgnorePattern(test.pattern)
+		require.True(t, ok, test.pattern)
+		assert.Equal(t, test.matched, p.match(test.path, test.isDir), "%s %s", test.pattern, test.path)
+	}
+
+	for _, line := range []string{"", "   ", "# comment", "/"} {
+		_, ok := parseIgnorePattern(line)
+		assert.False(t, ok, line)
+	}
+
+	p, ok := parseIgnorePattern("!keep.o")
+	require.True(t, ok)
+	assert.True(t, p.negate)
+
+	patterns := parseIgnorePatterns([]string{"*.o", "!keep.o"})
+	ignored, matched := matchPatterns(patterns, "keep.o", false)
+	assert.True(t, matched)
+	assert.False(t, ignored)
+	ignored, _ = matchPatterns(patterns, "drop.o", false)
+	assert.True(t, ignored)
+}
+
+func TestDetectDirFilters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enry")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		".gitignore":            "/build/\n*.gen.go\n",
+		".git/info/exclude":     "local.go\n",
+		"main.go":               "package main\n",
+		"local.go":              "package main\n",
+		"api.gen.go":            "package main\n",
+		"build/out.go":          "package build\n",
+		"src/app.py":            "print(1)\n",
+		"src/.ignore":           "*.py\n!keep.py\n",
+		"src/keep.py":           "print(1)\n",
+		"src/lib/util.go":       "package lib\n",
+		"src/lib/util_test.go":  "package lib\n",
+		"vendor/dep/dep.go":     "package dep\n",
+		"docs/example.go":       "package docs\n",
+		"tools/gen.sh":          "echo\n",
+		"tools/zz_generated.go": "// Code generated by stringer; DO NOT EDIT.\n\npackage tools\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	detector := newTestDetector(t)
+
+	// every file, for detectList to apply the same filters as detectDir
+	list, err := ioutil.TempFile("", "enry")
+	require.NoError(t, err)
+	defer os.Remove(list.Name())
+	for name := range files {
+		_, err := list.WriteString(filepath.FromSlash(name) + "\n")
+		require.NoError(t, err)
+	}
+	require.NoError(t, list.Close())
+
+	paths := func(opts walkOptions) []string {
+		results, _, err := detectDir(detector, dir, opts)
+		require.NoError(t, err)
+
+		var paths []string
+		for _, r := range results {
+			paths = append(paths, filepath.ToSlash(r.Path))
+		}
+		sort.Strings(paths)
+
+		listed, _, err := detectList(detector, dir, list.Name(), opts)
+		require.NoError(t, err)
+
+		var listedPaths []string
+		for _, r := range listed {
+			listedPaths = append(listedPaths, filepath.ToSlash(r.Path))
+		}
+		sort.Strings(listedPaths)
+		assert.Equal(t, paths, listedPaths, "listed %+v", opts)
+		return paths
+	}
+
+	all := []string{"api.gen.go", "build/out.go", "local.go", "main.go", "src/app.py", "src/keep.py", "src/lib/util.go", "src/lib/util_test.go", "tools/gen.sh"}
+	assert.Equal(t, all, paths(walkOptions{}))
+
+	assert.Equal(t, []string{"main.go", "src/keep.py", "src/lib/util.go", "src/lib/util_test.go", "tools/gen.sh"},
+		paths(walkOptions{gitignore: true}))
+
+	assert.Equal(t, []string{"main.go", "src/lib/util.go", "tools/gen.sh"}, paths(walkOptions{
+		gitignore: true,
+		exclude:   parseIgnorePatterns([]string{"*_test.go", "src/*.py"}),
+	}))
+
+	assert.Equal(t, []string{"src/app.py", "src/keep.py", "src/lib/util.go", "src/lib/util_test.go"}, paths(walkOptions{
+		include: parseIgnorePatterns([]string{"/src"}),
+	}))
+
+	assert.Equal(t, []string{"tools/gen.sh", "tools/zz_generated.go"}, paths(walkOptions{
+		include:          parseIgnorePatterns([]string{"/tools"}),
+		includeGenerated: true,
+	}))
+
+	results, generated, err := detectDir(detector, dir, walkOptions{include: parseIgnorePatterns([]string{"/tools"})})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, generated, 1)
+	assert.Equal(t, "Go", generated[0].Language)
+
+	assert.Equal(t, []string{"docs/example.go", "vendor/dep/dep.go"}, paths(walkOptions{
+		include:       parseIgnorePatterns([]string{"/docs/", "/vendor/"}),
+		includeVendor: true,
+		includeDocs:   true,
+	}))
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+// linguistLanguage is the breakdown of a language in the output of
+// github-linguist --json, and of --breakdown with its files.
+type linguistLanguage struct {
+	Size       int64    `json:"size"`
+	Percentage string   `json:"percentage"`
+	Files      []string `json:"files,omitempty"`
+}
+
+// printLinguistJSON prints the languages of out as github-linguist --json
+// does: their size in bytes and percentage of the total, from the largest to
+// the smallest, and their files if breakdown is set.
+func printLinguistJSON(root string, out map[string][]string, buf *bytes.Buffer, breakdown bool) error {
+	var total int64
+	languages := make(map[string]*linguistLanguage, len(out))
+	for language, files := range out {
+		l := &linguistLanguage{}
+		for _, file := range files {
+			fi, err := os.Stat(filepath.Join(root, file))
+			if err != nil {
+				return err
+			}
+
+			l.Size += fi.Size()
+			if breakdown {
+				l.Files = append(l.Files, filepath.ToSlash(file))
+			}
+		}
+
+		sort.Strings(l.Files)
+		languages[language] = l
+		total += l.Size
+	}
+
+	keys := make([]string, 0, len(languages))
+	for language, l := range languages {
+		if total > 0 {
+			l.Percentage = strconv.FormatFloat(float64(l.Size)/float64(total)*100, 'f', 2, 64)
+		} else {
+			l.Percentage = "0.00"
+		}
+		keys = append(keys, language)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := languages[keys[i]], languages[keys[j]]
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+		return keys[i] < keys[j]
+	})
+
+	// JSON objects are encoded sorted by key, so the languages are written
+	// one by one to keep them sorted by size, as Linguist does.
+	buf.WriteByte('{')
+	for i, language := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		if err := writeJSONMember(buf, language, languages[language]); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("}\n")
+	return nil
+}
+
+// linguistFile is the analysis of a file in the output of github-linguist --json.
+type linguistFile struct {
+	Lines     int     `json:"lines"`
+	Sloc      int     `json:"sloc"`
+	Type      string  `json:"type"`
+	MimeType  string  `json:"mime_type"`
+	Language  *string `json:"language"`
+	Large     bool    `json:"large"`
+	Generated bool    `json:"generated"`
+	Vendored  bool    `json:"vendored"`
+}
+
+// largeFileSize is the size above which Linguist considers a file large.
+const largeFileSize = 1024 * 1024
+
+// printLinguistFileJSON prints the analysis of a file as github-linguist --json does.
+func printLinguistFileJSON(buf *bytes.Buffer, file string, size int64, content []byte, f linguistFile) error {
+	f.Large = size > largeFileSize
+	f.Generated = enry.IsGenerated(file, content)
+	f.Vendored = enry.IsVendor(file)
+
+	buf.WriteByte('{')
+	if err := writeJSONMember(buf, file, f); err != nil {
+		return err
+	}
+	buf.WriteString("}\n")
+	return nil
+}
+
+// writeJSONMember writes the member of a JSON object with the given key and
+// value. Unlike json.Marshal, and like Linguist, it doesn't escape HTML.
+func writeJSONMember(buf *bytes.Buffer, key string, value interface{}) error {
+	e := json.NewEncoder(buf)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(key); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1)
+	buf.WriteByte(':')
+
+	if err := e.Encode(value); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+// listRecord is everything reported about a file by -list.
+type listRecord struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	// Strategy is the strategy that resolved the language, see enry.Detection.
+	Strategy      string `json:"strategy"`
+	Type          string `json:"type"`
+	MimeType      string `json:"mime_type"`
+	Bytes         int64  `json:"bytes"`
+	Lines         int    `json:"lines"`
+	Sloc          int    `json:"sloc"`
+	Vendored      bool   `json:"vendored"`
+	Generated     bool   `json:"generated"`
+	Documentation bool   `json:"documentation"`
+	Test          bool   `json:"test"`
+	// Symlink, Submodule and LFSPointer are set for the special files of the
+	// walk, which have no language. Target is what a symbolic link points to.
+	Symlink    bool   `json:"symlink,omitempty"`
+	Target     string `json:"target,omitempty"`
+	Submodule  bool   `json:"submodule,omitempty"`
+	LFSPointer bool   `json:"lfs_pointer,omitempty"`
+}
+
+// newListRecord returns the record of a file detected by the walk.
+func newListRecord(record fileRecord) listRecord {
+	return listRecord{
+		Path:          record.Path,
+		Language:      record.Language,
+		Strategy:      record.strategy,
+		Type:          record.Type,
+		MimeType:      record.MimeType,
+		Bytes:         record.Bytes,
+		Lines:         record.Lines,
+		Sloc:          record.Sloc,
+		Vendored:      enry.IsVendor(record.Path),
+		Generated:     record.Generated,
+		Documentation: enry.IsDocumentation(record.Path),
+		Test:          record.Test,
+	}
+}
+
+// specialRecords returns the records of the special files of a walk, if any.
+func specialRecords(special *specialFiles) []listRecord {
+	if special == nil {
+		return nil
+	}
+
+	var records []listRecord
+	for i, link := range special.symlinks {
+		records = append(records, listRecord{Path: filepath.ToSlash(link), Symlink: true, Target: special.targets[i]})
+	}
+	for _, dir := range special.submodules {
+		records = append(records, listRecord{Path: filepath.ToSlash(dir), Submodule: true})
+	}
+	for _, file := range special.lfsPointers {
+		records = append(records, listRecord{Path: filepath.ToSlash(file), LFSPointer: true})
+	}
+	return records
+}
+
+// flags returns the names of the flags set on the record, - if none.
+func (r listRecord) flags() string {
+	var flags []string
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"vendored", r.Vendored},
+		{"generated", r.Generated},
+		{"documentation", r.Documentation},
+		{"test", r.Test},
+		{"symlink", r.Symlink},
+		{"submodule", r.Submodule},
+		{"lfs-pointer", r.LFSPointer},
+	} {
+		if f.set {
+			flags = append(flags, f.name)
+		}
+	}
+
+	if len(flags) == 0 {
+		return "-"
+	}
+	return strings.Join(flags, ",")
+}
+
+// printList prints a record per file of the results and of the generated
+// files left out of them, sorted by path, then of the special files, if not
+// nil, in the given format: a line per file for text, a JSON array for json,
+// a JSON object per line for json-lines, or a row per file for the tabular
+// formats.
+func printList(w io.Writer, format string, results, generated []fileRecord, special *specialFiles) error {
+	files := allFileRecords(results, generated)
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	records := make([]listRecord, 0, len(files))
+	for _, r := range files {
+		records = append(records, newListRecord(r))
+	}
+	records = append(records, specialRecords(special)...)
+
+	switch format {
+	case "json":
+		return json.NewEncoder(w).Encode(records)
+	case "json-lines":
+		e := json.NewEncoder(w)
+		for _, r := range records {
+			if err := e.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "text":
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "path\tlanguage\tstrategy\ttype\tmime_type\tbytes\tlines\tsloc\tflags")
+		for _, r := range records {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+				r.Path, r.Language, r.Strategy, r.Type, r.MimeType, r.Bytes, r.Lines, r.Sloc, r.flags())
+		}
+		return tw.Flush()
+	}
+
+	header := []string{"path", "language", "strategy", "type", "mime_type", "bytes", "lines", "sloc", "vendored", "generated", "documentation", "test"}
+	var rows [][]string
+	for _, r := range records {
+		rows = append(rows, []string{
+			r.Path,
+			r.Language,
+			r.Strategy,
+			r.Type,
+			r.MimeType,
+			strconv.FormatInt(r.Bytes, 10),
+			strconv.Itoa(r.Lines),
+			strconv.Itoa(r.Sloc),
+			strconv.FormatBool(r.Vendored),
+			strconv.FormatBool(r.Generated),
+			strconv.FormatBool(r.Documentation),
+			strconv.FormatBool(r.Test),
+		})
+	}
+
+	switch format {
+	case "markdown":
+		return writeMarkdown(w, header, rows, 5)
+	case "tsv":
+		return writeCSV(w, '\t', header, rows)
+	default:
+		return writeCSV(w, ',', header, rows)
+	}
+}
@@ -13,9 +13,11 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-enry/go-enry/v2"
 	"github.com/go-enry/go-enry/v2/data"
+	"github.com/go-enry/go-enry/v2/tokenizer"
 )
 
 var (
@@ -25,135 +27,246 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "badge" {
+		if err := badge(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	flag.Usage = usage
 	breakdownFlag := flag.Bool("breakdown", false, "")
 	jsonFlag := flag.Bool("json", false, "")
+	linguistFlag := flag.Bool("linguist", false, "With -json, print the same JSON as github-linguist --json, with the files of every language if -breakdown is set")
 	showVersion := flag.Bool("version", false, "Show the enry version information")
 	allLangs := flag.Bool("all", false, "Show all files, including those identified as non-programming languages")
 	countMode := flag.String("mode", "byte", "the method used to count file size. Available options are: file, line and byte")
-	limitKB := flag.Int64("limit", 16*1024, "Analyse first N KB of the file (-1 means no limit)")
+	sample := flag.String("sample", "head", "the parts of the file tokenized by the classifier. Available options are: head, head-tail and windows")
+	windows := flag.Int("windows", 4, "With -sample=windows, the number of windows, spread evenly over the file")
+	format := flag.String("format", "text", "the output format. Available options are: text, csv, tsv, markdown, json and json-lines")
+	list := flag.Bool("list", false, "Print a record per file, with its language, the strategy that detected it, type, MIME type, size and flags, as JSON lines given -json, or in any -format")
+	byDir := flag.Int("by-dir", 0, "Break the languages down by directory, up to N levels deep (0 means no breakdown). Every file counts in its deepest directory up to N only, not in the ones above it")
+	filters := newWalkFlags(flag.CommandLine)
+	filesFrom := flag.String("files-from", "", "Analyse the files listed in a file, one per line, relative to <path>, instead of walking <path> (- means stdin)")
+	stdin := flag.Bool("stdin", false, "Analyse the content read from stdin, as a single file")
+	filename := flag.String("filename", "", "With -stdin, the name of the file, used to detect its language")
+	showProgress := flag.Bool("progress", isTerminal(os.Stderr), "Report the number of files detected on stderr, when the walk takes longer than a second")
+	skipHeader := flag.Bool("skip-header", false, "Don't tokenize the comment block at the start of the file in the classifier")
 	flag.Parse()
-	limit := (*limitKB) * 1024
 
 	if *showVersion {
 		fmt.Println(version)
 		return
 	}
 
-	root, err := filepath.Abs(flag.Arg(0))
+	if !validFormat(*format) {
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	opts, limit, err := tokenizerOptions(*sample, *filters.limit, *windows, *skipHeader)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	fileInfo, err := os.Stat(root)
+	detector, err := enry.NewDetector(enry.WithTokenizer(opts))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if fileInfo.Mode().IsRegular() {
-		err = printFileAnalysis(root, limit, *jsonFlag)
+	if *stdin {
+		err := printStdinAnalysis(detector, *filename, limit, *format, *countMode, *jsonFlag, *jsonFlag && *linguistFlag)
 		if err != nil {
-			fmt.Println(err)
+			log.Fatal(err)
 		}
 		return
 	}
 
-	out := make(map[string][]string, 0)
-	err = filepath.Walk(root, func(path string, f os.FileInfo, err error) error {
+	root, err := filepath.Abs(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(root)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	listFormat := *format
+	if listFormat == "text" && *jsonFlag {
+		listFormat = "json-lines"
+	}
+
+	if *filesFrom == "" && fileInfo.Mode().IsRegular() && (*list || *format != "text") {
+		record, err := detectFile(detector, root, limit)
 		if err != nil {
-			log.Println(err)
-			return filepath.SkipDir
+			log.Fatal(err)
 		}
 
-		if !f.Mode().IsDir() && !f.Mode().IsRegular() {
-			return nil
+		if *list {
+			err = printList(os.Stdout, listFormat, []fileRecord{record}, nil, nil)
+		} else {
+			err = printFormat(os.Stdout, *format, []fileRecord{record}, nil, *countMode)
 		}
-
-		relativePath, err := filepath.Rel(root, path)
 		if err != nil {
-			log.Println(err)
-			return nil
+			log.Fatal(err)
 		}
+		return
+	}
 
-		if relativePath == "." {
-			return nil
+	if *filesFrom == "" && fileInfo.Mode().IsRegular() {
+		err = printFileAnalysis(detector, root, limit, *jsonFlag, *jsonFlag && *linguistFlag)
+		if err != nil {
+			fmt.Println(err)
 		}
+		return
+	}
 
-		if f.IsDir() {
-			relativePath = relativePath + "/"
-		}
+	walk := filters.options()
+	walk.limit = limit
+	walk.countLines = *list || *byDir > 0 || *format != "text" || *jsonFlag
+	walk.allLangs = *allLangs
+	walk.special = &specialFiles{}
+	if *showProgress {
+		walk.progress = newProgress(os.Stderr, time.Second, 200*time.Millisecond)
+	}
 
-		if enry.IsVendor(relativePath) || enry.IsDotFile(relativePath) ||
-			enry.IsDocumentation(relativePath) || enry.IsConfiguration(relativePath) {
-			// TODO(bzz): skip enry.IsGeneratedPath() after https://github.com/src-d/enry/issues/213
-			if f.IsDir() {
-				return filepath.SkipDir
-			}
+	var results, generated []fileRecord
+	if *filesFrom != "" {
+		results, generated, err = detectList(detector, root, *filesFrom, walk)
+	} else {
+		results, generated, err = detectDir(detector, root, walk)
+	}
+	walk.progress.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
 
-			return nil
+	if *list {
+		if err := printList(os.Stdout, listFormat, results, generated, walk.special); err != nil {
+			log.Fatal(err)
 		}
+		return
+	}
 
-		if f.IsDir() {
-			return nil
+	if *byDir > 0 {
+		byDirFormat := *format
+		if byDirFormat == "text" && *jsonFlag {
+			byDirFormat = "json"
 		}
 
-		// TODO(bzz): provide API that mimics linguist CLI output for
-		// - running ByExtension & ByFilename
-		// - reading the file, if that did not work
-		// - GetLanguage([]Strategy)
-		content, err := readFile(path, limit)
-		if err != nil {
-			log.Println(err)
-			return nil
+		if err := printByDir(os.Stdout, byDirFormat, allFileRecords(results, generated), *byDir, *countMode); err != nil {
+			log.Fatal(err)
 		}
-		// TODO(bzz): skip enry.IsGeneratedContent() as well, after https://github.com/src-d/enry/issues/213
+		return
+	}
 
-		language := enry.GetLanguage(filepath.Base(path), content)
-		if language == enry.OtherLanguage {
-			return nil
+	if *format != "text" {
+		if err := printFormat(os.Stdout, *format, results, generated, *countMode); err != nil {
+			log.Fatal(err)
 		}
+		return
+	}
 
-		// If we are not asked to display all, do as
-		// https://github.com/github/linguist/blob/bf95666fc15e49d556f2def4d0a85338423c25f3/lib/linguist/blob_helper.rb#L382
-		if !*allLangs &&
-			enry.GetLanguageType(language) != enry.Programming &&
-			enry.GetLanguageType(language) != enry.Markup {
-			return nil
+	// -json is -format=json, with the files of every language given
+	// -breakdown, so that the generated bytes are reported as well
+	if *jsonFlag && !*linguistFlag {
+		if err := printLanguagesJSON(*breakdownFlag)(os.Stdout, allFileRecords(results, generated), *countMode); err != nil {
+			log.Fatal(err)
 		}
-
-		out[language] = append(out[language], relativePath)
-		return nil
-	})
-
-	if err != nil {
-		log.Fatal(err)
+		return
 	}
 
+	out := groupByLanguage(results)
 	var buf bytes.Buffer
 	switch {
-	case *jsonFlag && !*breakdownFlag:
-		printJson(out, &buf)
-	case *jsonFlag && *breakdownFlag:
-		printBreakDown(out, &buf)
+	case *jsonFlag:
+		if err := printLinguistJSON(root, out, &buf, *breakdownFlag); err != nil {
+			log.Fatal(err)
+		}
 	case *breakdownFlag:
 		printPercents(root, out, &buf, *countMode)
 		buf.WriteByte('\n')
 		printBreakDown(out, &buf)
+		printSpecialFiles(&buf, walk.special)
 	default:
 		printPercents(root, out, &buf, *countMode)
+		if !walk.special.empty() {
+			buf.WriteByte('\n')
+			printSpecialFiles(&buf, walk.special)
+		}
 	}
 
 	fmt.Print(buf.String())
 }
 
+// patternsFlag is a flag that can be repeated, collecting .gitignore patterns.
+type patternsFlag []string
+
+func (f *patternsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *patternsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// tokenizerOptions returns the options of the classifier tokenizer for the
+// given -sample, -limit, -windows and -skip-header flags, and the number of
+// bytes of the files to read for them, 0 meaning the whole files. Only the
+// head of a file is read if the classifier only samples its head.
+func tokenizerOptions(sample string, limitKB, windows int, skipHeader bool) (tokenizer.Options, int64, error) {
+	opts := tokenizer.Options{
+		Limit:               limitKB * 1024,
+		SkipLeadingComments: skipHeader,
+	}
+	if limitKB < 0 {
+		opts.Limit = -1
+	}
+
+	switch sample {
+	case "head":
+		opts.Sampler = tokenizer.Head
+	case "head-tail":
+		opts.Sampler = tokenizer.HeadTail
+	case "windows":
+		if windows < 1 {
+			return opts, 0, fmt.Errorf("invalid number of windows %d", windows)
+		}
+		opts.Sampler = tokenizer.Windows(windows)
+	default:
+		return opts, 0, fmt.Errorf("unknown sample %q", sample)
+	}
+
+	// the other samplers, and the leading comments skipped, can be
+	// anywhere in the file
+	if sample != "head" || skipHeader || opts.Limit < 0 {
+		return opts, 0, nil
+	}
+
+	if opts.Limit == 0 {
+		return opts, tokenizer.ByteLimit, nil
+	}
+	return opts, int64(opts.Limit), nil
+}
+
 func usage() {
 	fmt.Fprintf(
 		os.Stderr,
 		`  %[1]s %[2]s build: %[3]s commit: %[4]s, based on linguist commit: %[5]s
   %[1]s, A simple (and faster) implementation of github/linguist
-  usage: %[1]s [-mode=(file|line|byte)] [-prog] <path>
+  usage: %[1]s [-mode=(file|line|byte)] [-prog] [-limit=N] [-sample=(head|head-tail|windows)] [-windows=N] [-skip-header] <path>
          %[1]s [-mode=(file|line|byte)] [-prog] [-json] [-breakdown] <path>
+         %[1]s [-json -linguist] [-breakdown] <path>
+         %[1]s [-mode=(file|line|byte)] [-all] [-format=(text|csv|tsv|markdown|json|json-lines)] <path>
+         %[1]s [-mode=(file|line|byte)] [-all] [-json] [-format=(text|csv|tsv|markdown|json|json-lines)] -by-dir=N <path>
+         %[1]s [-json] [-format=(text|csv|tsv|markdown|json|json-lines)] -list <path>
+         %[1]s [-j=N] [-progress] <path>
+         %[1]s [-exclude=<pattern>]... [-include=<pattern>]... [-gitignore] [-include-vendor] [-include-docs] [-include-generated] <path>
+         %[1]s [-mode=(file|line|byte)] [-all] [-json] [-breakdown] [-format=(text|csv|tsv|markdown|json|json-lines)] -files-from=(<file>|-) [<path>]
+         %[1]s [-json [-linguist]] [-format=(text|csv|tsv|markdown|json|json-lines)] -stdin [-filename=<name>]
          %[1]s [-mode=(file|line|byte)] [-prog] [-json] [-breakdown]
+         %[1]s badge [<flags>] <path>
          %[1]s [-version]
 `,
 		os.Args[0], version, build, commit, data.LinguistCommit[:7],
@@ -161,9 +274,15 @@ func usage() {
 }
 
 func printBreakDown(out map[string][]string, buff *bytes.Buffer) {
-	for name, language := range out {
+	names := make([]string, 0, len(out))
+	for name := range out {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
 		fmt.Fprintln(buff, name)
-		for _, file := range language {
+		for _, file := range out[name] {
 			fmt.Fprintln(buff, file)
 		}
 
@@ -171,10 +290,6 @@ func printBreakDown(out map[string][]string, buff *bytes.Buffer) {
 	}
 }
 
-func printJson(out map[string][]string, buf *bytes.Buffer) {
-	json.NewEncoder(buf).Encode(out)
-}
-
 // filelistError represents a failed operation that took place across multiple files.
 type filelistError []string
 
@@ -213,7 +328,10 @@ func printPercents(root string, fSummary map[string][]string, buff *bytes.Buffer
 
 	// Slice the keys by their quantity (file count, line count, byte size, etc.).
 	sort.Slice(keys, func(i, j int) bool {
-		return fileValues[keys[i]] > fileValues[keys[j]]
+		if fileValues[keys[i]] != fileValues[keys[j]] {
+			return fileValues[keys[i]] > fileValues[keys[j]]
+		}
+		return keys[i] < keys[j]
 	})
 
 	// Calculate and write percentages of each file type.
@@ -261,12 +379,55 @@ func byteCountValues(root string, files []string) (float64, filelistError) {
 	return t, filesErr
 }
 
-func printFileAnalysis(file string, limit int64, isJSON bool) error {
+// detectFile detects the language of a single file, whose record has its base name as path.
+func detectFile(detector *enry.Detector, file string, limit int64) (fileRecord, error) {
+	content, stats, err := loadFile(file, limit, true)
+	if err != nil {
+		return fileRecord{}, err
+	}
+
+	stats.generated = enry.IsGenerated(filepath.Base(file), content)
+	return newFileRecord(detectContent(detector, file, content), stats), nil
+}
+
+// detectContent detects the language of the content of a file, whose Result
+// has its base name as path.
+func detectContent(detector *enry.Detector, file string, content []byte) enry.Result {
+	detection := detector.Detect(file, content)
+	return enry.Result{
+		Path:      filepath.Base(file),
+		Language:  detection.Language,
+		Languages: detection.Candidates,
+		Status:    detection.Status,
+		Strategy:  detection.Strategy,
+	}
+}
+
+// fileAnalysis is the content of a file to analyse, and what is known
+// about the whole of it if the content is only a sample.
+type fileAnalysis struct {
+	// name is the file as given by the user, and file the path it is
+	// detected by.
+	name, file string
+	// content are the first bytes of the file, up to the limit.
+	content              []byte
+	size                 int64
+	totalLines, nonBlank int
+}
+
+// printFileAnalysis prints the analysis of a file, as github-linguist --json
+// does if linguist is set.
+func printFileAnalysis(detector *enry.Detector, file string, limit int64, isJSON, linguist bool) error {
 	data, err := readFile(file, limit)
 	if err != nil {
 		return err
 	}
 
+	fi, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
 	isSample := limit > 0 && len(data) == int(limit)
 
 	full := data
@@ -276,18 +437,77 @@ func printFileAnalysis(file string, limit int64, isJSON bool) error {
 	}
 
 	totalLines, nonBlank := getLines(file, full)
+	return printAnalysis(detector, fileAnalysis{
+		name:       flag.Arg(0),
+		file:       file,
+		content:    data,
+		size:       fi.Size(),
+		totalLines: totalLines,
+		nonBlank:   nonBlank,
+	}, isJSON, linguist)
+}
+
+// printStdinAnalysis prints the analysis of the content read from stdin, as
+// if it were the content of filename, in the given format.
+func printStdinAnalysis(detector *enry.Detector, filename string, limit int64, format, mode string, isJSON, linguist bool) error {
+	content, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	data := content
+	if limit > 0 && int64(len(data)) > limit {
+		data = data[:limit]
+	}
+
+	if format != "text" {
+		r := detectContent(detector, filename, data)
+		return formats[format](os.Stdout, []fileRecord{contentRecord(r, content)}, mode)
+	}
+
+	name := filename
+	if name == "" {
+		name = "-"
+	}
+
+	totalLines, nonBlank := getLines(filename, content)
+	return printAnalysis(detector, fileAnalysis{
+		name:       name,
+		file:       filename,
+		content:    data,
+		size:       int64(len(content)),
+		totalLines: totalLines,
+		nonBlank:   nonBlank,
+	}, isJSON, linguist)
+}
 
+// printAnalysis prints the analysis of a file, see printFileAnalysis.
+func printAnalysis(detector *enry.Detector, a fileAnalysis, isJSON, linguist bool) error {
 	// functions below can work on a sample
-	fileType := getFileType(file, data)
-	language := enry.GetLanguage(file, data)
-	mimeType := enry.GetMIMEType(file, language)
-	vendored := enry.IsVendor(file)
+	fileType := getFileType(a.file, a.content)
+	language := detector.GetLanguage(a.file, a.content)
+	mimeType := enry.GetMIMEType(a.file, language)
+	vendored := enry.IsVendor(a.file)
+
+	if linguist {
+		f := linguistFile{Lines: a.totalLines, Sloc: a.nonBlank, Type: fileType, MimeType: mimeType}
+		if language != enry.OtherLanguage {
+			f.Language = &language
+		}
+
+		var buf bytes.Buffer
+		if err := printLinguistFileJSON(&buf, a.name, a.size, a.content, f); err != nil {
+			return err
+		}
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
 
 	if isJSON {
 		return json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
-			"filename":    filepath.Base(file),
-			"lines":       nonBlank,
-			"total_lines": totalLines,
+			"filename":    filepath.Base(a.name),
+			"lines":       a.nonBlank,
+			"total_lines": a.totalLines,
 			"type":        fileType,
 			"mime":        mimeType,
 			"language":    language,
@@ -302,7 +522,7 @@ func printFileAnalysis(file string, limit int64, isJSON bool) error {
   language:  %s
   vendored:  %t
 `,
-		filepath.Base(file), totalLines, nonBlank, fileType, mimeType, language, vendored,
+		filepath.Base(a.name), a.totalLines, a.nonBlank, fileType, mimeType, language, vendored,
 	)
 	return nil
 }
@@ -344,7 +564,17 @@ func getLines(file string, content []byte) (total, blank int) {
 		defer f.Close()
 		r = f
 	}
+	total, nonBlank, err := countFileLines(r)
+	if err != nil {
+		fmt.Println(err)
+	}
+	return total, nonBlank
+}
+
+// countFileLines returns the number of lines read from r, and of the non-blank ones.
+func countFileLines(r io.Reader) (total, nonBlank int, err error) {
 	br := bufio.NewReader(r)
+	var blank int
 	lastBlank := true
 	empty := true
 	for {
@@ -352,8 +582,7 @@ func getLines(file string, content []byte) (total, blank int) {
 		if err == io.EOF {
 			break
 		} else if err != nil {
-			fmt.Println(err)
-			break
+			return total, total - blank, err
 		}
 		if prefix {
 			continue
@@ -369,8 +598,7 @@ func getLines(file string, content []byte) (total, blank int) {
 		total++
 		blank++
 	}
-	nonBlank := total - blank
-	return total, nonBlank
+	return total, total - blank, nil
 }
 
 func getFileType(file string, content []byte) string {
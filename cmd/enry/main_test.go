@@ -1,9 +1,37 @@
 package main
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/tokenizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// testRepo is the repository the Linguist fixtures are generated from.
+var testRepo = filepath.Join("testdata", "linguist", "repo")
+
+// newTestDetector returns a Detector with the default configuration.
+func newTestDetector(t *testing.T) *enry.Detector {
+	detector, err := enry.NewDetector()
+	require.NoError(t, err)
+	return detector
+}
+
+// detectTestRepo detects the languages of the files of testRepo, see detectDir.
+func detectTestRepo(t *testing.T, opts walkOptions) (results, generated []fileRecord) {
+	results, generated, err := detectDir(newTestDetector(t), testRepo, opts)
+	require.NoError(t, err)
+	return results, generated
+}
+
 func TestGetLines(t *testing.T) {
 	tests := []struct {
 		content      string
@@ -36,3 +64,284 @@ func TestGetLines(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenizerOptions(t *testing.T) {
+	tests := []struct {
+		sample     string
+		limitKB    int
+		skipHeader bool
+		limit      int
+		read       int64
+	}{
+		{"head", 0, false, 0, tokenizer.ByteLimit},
+		{"head", 16, false, 16 * 1024, 16 * 1024},
+		{"head", -1, false, -1, 0},
+		{"head", 16, true, 16 * 1024, 0},
+		{"head-tail", 16, false, 16 * 1024, 0},
+		{"windows", 0, false, 0, 0},
+	}
+
+	for _, test := range tests {
+		opts, read, err := tokenizerOptions(test.sample, test.limitKB, 4, test.skipHeader)
+		require.NoError(t, err)
+		assert.Equal(t, test.limit, opts.Limit, "%+v", test)
+		assert.Equal(t, test.skipHeader, opts.SkipLeadingComments, "%+v", test)
+		assert.Equal(t, test.read, read, "%+v", test)
+	}
+
+	_, _, err := tokenizerOptions("tail", 0, 4, false)
+	assert.EqualError(t, err, `unknown sample "tail"`)
+	_, _, err = tokenizerOptions("windows", 0, 0, false)
+	assert.EqualError(t, err, "invalid number of windows 0")
+}
+
+func TestLinguistJSON(t *testing.T) {
+	results, _ := detectTestRepo(t, walkOptions{})
+	out := groupByLanguage(results)
+
+	for golden, breakdown := range map[string]bool{"languages.json": false, "breakdown.json": true} {
+		expected, err := ioutil.ReadFile(filepath.Join("testdata", "linguist", golden))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, printLinguistJSON(testRepo, out, &buf, breakdown))
+		assert.Equal(t, string(expected), buf.String(), golden)
+	}
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "linguist", "file.json"))
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(testRepo, "main.go"))
+	require.NoError(t, err)
+
+	total, sloc := getLines("", content)
+	language := enry.GetLanguage("main.go", content)
+	f := linguistFile{Lines: total, Sloc: sloc, Type: getFileType("main.go", content), MimeType: enry.GetMIMEType("main.go", language), Language: &language}
+
+	var buf bytes.Buffer
+	require.NoError(t, printLinguistFileJSON(&buf, "main.go", int64(len(content)), content, f))
+	assert.Equal(t, string(expected), buf.String())
+}
+
+func TestFormats(t *testing.T) {
+	results, generated := detectTestRepo(t, walkOptions{countLines: true})
+
+	tests := []struct {
+		format   string
+		mode     string
+		expected string
+	}{
+		{format: "csv", mode: "byte", expected: `language,type,group,color,files,bytes,lines,percentage,generated_bytes
+Go,programming,,#00ADD8,2,168,13,64.86,57
+HTML,markup,,#e34c26,1,59,6,22.78,0
+Shell,programming,,#89e051,1,32,3,12.36,0
+`},
+		{format: "tsv", mode: "file", expected: "language\ttype\tgroup\tcolor\tfiles\tbytes\tlines\tpercentage\tgenerated_bytes\n" +
+			"Go\tprogramming\t\t#00ADD8\t2\t168\t13\t50.00\t57\n" +
+			"HTML\tmarkup\t\t#e34c26\t1\t59\t6\t25.00\t0\n" +
+			"Shell\tprogramming\t\t#89e051\t1\t32\t3\t25.00\t0\n"},
+		{format: "markdown", mode: "line", expected: `| language | type | group | color | files | bytes | lines | percentage | generated_bytes |
+| --- | --- | --- | --- | ---: | ---: | ---: | ---: | ---: |
+| Go | programming |  | #00ADD8 | 2 | 168 | 13 | 59.09% | 57 |
+| HTML | markup |  | #e34c26 | 1 | 59 | 6 | 27.27% | 0 |
+| Shell | programming |  | #89e051 | 1 | 32 | 3 | 13.64% | 0 |
+`},
+		{format: "json", mode: "byte", expected: `[{"language":"Go","type":"programming","color":"#00ADD8","files":2,"bytes":168,"lines":13,"percentage":64.86486486486487,"generated_bytes":57},` +
+			`{"language":"HTML","type":"markup","color":"#e34c26","files":1,"bytes":59,"lines":6,"percentage":22.779922779922778,"generated_bytes":0},` +
+			`{"language":"Shell","type":"programming","color":"#89e051","files":1,"bytes":32,"lines":3,"percentage":12.355212355212355,"generated_bytes":0}]
+`},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		require.NoError(t, printFormat(&buf, test.format, results, generated, test.mode))
+		assert.Equal(t, test.expected, buf.String(), test.format)
+	}
+
+	// -json -breakdown lists the files counted, the generated ones only in
+	// generated_bytes
+	var buf bytes.Buffer
+	require.NoError(t, printLanguagesJSON(true)(&buf, allFileRecords(results, generated), "file"))
+	assert.Equal(t, `[{"language":"Go","type":"programming","color":"#00ADD8","files":2,"bytes":168,"lines":13,"percentage":50,"generated_bytes":57,"paths":["lib/add.go","main.go"]},`+
+		`{"language":"HTML","type":"markup","color":"#e34c26","files":1,"bytes":59,"lines":6,"percentage":25,"generated_bytes":0,"paths":["web/index.html"]},`+
+		`{"language":"Shell","type":"programming","color":"#89e051","files":1,"bytes":32,"lines":3,"percentage":25,"generated_bytes":0,"paths":["build.sh"]}]
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, printFormat(&buf, "json-lines", results, generated, "byte"))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, `{"path":"lib/add.go","language":"Go","languages":["Go"],"type":"programming","color":"#00ADD8","mime_type":"text/x-go","bytes":89,"lines":6,"sloc":5,"test":false,"generated":false}`, lines[1])
+
+	assert.True(t, validFormat("text"))
+	assert.False(t, validFormat("xml"))
+}
+
+func TestByDir(t *testing.T) {
+	for file, expected := range map[string]string{
+		"main.go":       ".",
+		"lib/add.go":    "lib",
+		"a/b/c/d.go":    "a/b",
+		"a/b.go":        "a",
+		"a/b/c/d/e.txt": "a/b",
+	} {
+		assert.Equal(t, expected, dirPrefix(file, 2), file)
+	}
+
+	results, generated := detectTestRepo(t, walkOptions{countLines: true})
+
+	summaries := dirBreakdown(allFileRecords(results, generated), 1, "byte")
+	require.Len(t, summaries, 3)
+	assert.Equal(t, ".", summaries[0].Dir)
+	assert.Equal(t, 2, summaries[0].Files)
+	assert.Equal(t, int64(111), summaries[0].Bytes)
+	assert.Equal(t, "Go", summaries[0].Languages[0].Language)
+	assert.InDelta(t, 79.0/111*100, summaries[0].Languages[0].Percentage, 1e-9)
+
+	var buf bytes.Buffer
+	require.NoError(t, printByDir(&buf, "text", allFileRecords(results, generated), 1, "file"))
+	assert.Equal(t, `dir  files  languages
+.    2      Go 50.00%, Shell 50.00%
+lib  1      Go 100.00%
+web  1      HTML 100.00%
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, printByDir(&buf, "csv", allFileRecords(results, generated), 1, "byte"))
+	assert.Equal(t, `dir,language,files,bytes,lines,percentage,generated_bytes
+.,Go,1,79,7,71.17,0
+.,Shell,1,32,3,28.83,0
+lib,Go,1,89,6,100.00,57
+web,HTML,1,59,6,100.00,0
+`, buf.String())
+}
+
+func TestDetectList(t *testing.T) {
+	root, err := filepath.Abs(testRepo)
+	require.NoError(t, err)
+	abs := filepath.Join(root, "build.sh")
+	require.NoError(t, err)
+
+	list, err := ioutil.TempFile("", "enry")
+	require.NoError(t, err)
+	defer os.Remove(list.Name())
+	_, err = list.WriteString("main.go\n\n./lib/add.go\nvendor/dep/dep.go\nlib/zz_generated.go\nREADME.md\n" + abs + "\n")
+	require.NoError(t, err)
+	require.NoError(t, list.Close())
+
+	results, generated, err := detectList(newTestDetector(t), root, list.Name(), walkOptions{})
+	require.NoError(t, err)
+
+	var paths []string
+	for _, r := range results {
+		paths = append(paths, filepath.ToSlash(r.Path))
+	}
+	assert.Equal(t, []string{"main.go", "lib/add.go", "build.sh"}, paths)
+	require.Len(t, generated, 1)
+	assert.Equal(t, "lib/zz_generated.go", filepath.ToSlash(generated[0].Path))
+}
+
+func TestList(t *testing.T) {
+	results, generated := detectTestRepo(t, walkOptions{includeVendor: true, countLines: true})
+
+	var buf bytes.Buffer
+	require.NoError(t, printList(&buf, "text", results, generated, nil))
+	assert.Equal(t, `path                 language  strategy    type         mime_type  bytes  lines  sloc  flags
+build.sh             Shell     shebang     programming  text/x-sh  32     3      3     -
+lib/add.go           Go        extension   programming  text/x-go  89     6      5     -
+lib/zz_generated.go  Go        extension   programming  text/x-go  57     3      2     generated
+main.go              Go        extension   programming  text/x-go  79     7      5     -
+vendor/dep/dep.go    Go        extension   programming  text/x-go  12     1      1     vendored
+web/index.html       HTML      heuristics  markup       text/html  59     6      6     -
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, printList(&buf, "csv", results[:1], nil, nil))
+	assert.Equal(t, "path,language,strategy,type,mime_type,bytes,lines,sloc,vendored,generated,documentation,test\n"+
+		"build.sh,Shell,shebang,programming,text/x-sh,32,3,3,false,false,false,false\n", buf.String())
+}
+
+func TestDetectDirOptions(t *testing.T) {
+	expected, expectedGenerated := detectTestRepo(t, walkOptions{jobs: 1, countLines: true})
+
+	// the results are in the order of the walk whatever the number of jobs,
+	// and the stats are of the whole files whatever the part read for detection
+	for _, opts := range []walkOptions{{jobs: 2}, {jobs: 8}, {limit: 64}} {
+		opts.countLines = true
+		results, generated := detectTestRepo(t, opts)
+		assert.Equal(t, expected, results, "%+v", opts)
+		assert.Equal(t, expectedGenerated, generated, "%+v", opts)
+	}
+
+	// the lines are only counted on demand
+	results, _ := detectTestRepo(t, walkOptions{limit: 64})
+	for i, r := range results {
+		assert.Equal(t, expected[i].Bytes, r.Bytes, r.Path)
+		assert.Zero(t, r.Lines, r.Path)
+		assert.Zero(t, r.Sloc, r.Path)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	// the ticks are sent on an unbuffered channel, so every one of them is
+	// handled before the next one, or Close, and the output is only read
+	// once Close has waited for the progress to stop
+	var buf bytes.Buffer
+	ticks := make(chan time.Time)
+	p := startProgress(&buf, 0, ticks, func() {})
+	for i := 0; i < 3; i++ {
+		p.add()
+	}
+	ticks <- time.Now()
+	p.Close()
+	assert.Equal(t, "\renry: 3 files in 0s\renry: 3 files in 0s\n", buf.String())
+
+	buf.Reset()
+	stopped := false
+	p = startProgress(&buf, time.Hour, ticks, func() { stopped = true })
+	p.add()
+	ticks <- time.Now()
+	p.Close()
+	assert.Empty(t, buf.String())
+	assert.True(t, stopped)
+
+	var nilProgress *progress
+	nilProgress.add()
+	nilProgress.Close()
+}
+
+func TestSpecialFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "enry")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		".gitmodules": "[submodule \"mod\"]\n\tpath = mod\n\turl = https://example.com/mod.git\n",
+		"main.go":     "package main\n",
+		"big.go":      "version https://git-lfs.github.com/spec/v1\noid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\nsize 12345\n",
+		"sub/.git":    "gitdir: ../.git/modules/sub\n",
+		"sub/sub.go":  "package sub\n",
+		"mod/mod.go":  "package mod\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	require.NoError(t, os.Symlink("main.go", filepath.Join(dir, "link.go")))
+
+	special := &specialFiles{}
+	results, _, err := detectDir(newTestDetector(t), dir, walkOptions{special: special})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "main.go", results[0].Path)
+
+	assert.Equal(t, []string{"link.go"}, special.symlinks)
+	assert.Equal(t, []string{"main.go"}, special.targets)
+	assert.Equal(t, []string{"mod", "sub"}, special.submodules)
+	assert.Equal(t, []string{"big.go"}, special.lfsPointers)
+
+	var buf bytes.Buffer
+	printSpecialFiles(&buf, special)
+	assert.Equal(t, "Symlinks\nlink.go -> main.go\n\nSubmodules\nmod\nsub\n\nGit LFS pointers\nbig.go\n\n", buf.String())
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// progress reports the number of files detected so far by a walk, on a
+// single line rewritten every interval, once the walk has taken longer than
+// a delay, so that small trees print nothing.
+type progress struct {
+	// files is the number of files detected, updated atomically.
+	files int64
+	w     io.Writer
+	delay time.Duration
+	start time.Time
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// newProgress starts reporting the progress on w.
+func newProgress(w io.Writer, delay, interval time.Duration) *progress {
+	ticker := time.NewTicker(interval)
+	return startProgress(w, delay, ticker.C, ticker.Stop)
+}
+
+// startProgress starts reporting the progress on w on every tick, calling
+// stopTicks once it is closed.
+func startProgress(w io.Writer, delay time.Duration, ticks <-chan time.Time, stopTicks func()) *progress {
+	p := &progress{
+		w:     w,
+		delay: delay,
+		start: time.Now(),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	go p.run(ticks, stopTicks)
+	return p
+}
+
+func (p *progress) run(ticks <-chan time.Time, stopTicks func()) {
+	defer close(p.done)
+	defer stopTicks()
+
+	var shown bool
+	for {
+		select {
+		case <-ticks:
+			if time.Since(p.start) >= p.delay {
+				p.print()
+				shown = true
+			}
+		case <-p.stop:
+			if shown {
+				p.print()
+				fmt.Fprintln(p.w)
+			}
+			return
+		}
+	}
+}
+
+func (p *progress) print() {
+	fmt.Fprintf(p.w, "\renry: %d files in %s", atomic.LoadInt64(&p.files), time.Since(p.start).Round(time.Second))
+}
+
+// add counts a detected file. It is safe to call on a nil progress.
+func (p *progress) add() {
+	if p != nil {
+		atomic.AddInt64(&p.files, 1)
+	}
+}
+
+// Close stops reporting the progress, ending the line printed, if any.
+// It is safe to call on a nil progress.
+func (p *progress) Close() {
+	if p == nil {
+		return
+	}
+
+	close(p.stop)
+	<-p.done
+}
+
+// isTerminal tells whether f is a terminal, rather than a file or a pipe.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// specialFiles are the paths found by a walk that aren't analysed as
+// source, relative to its root, in the order of the walk.
+type specialFiles struct {
+	// symlinks are the symbolic links, which are not followed, and targets
+	// what they point to.
+	symlinks, targets []string
+	// submodules are the directories of git submodules, which are not walked.
+	submodules []string
+	// lfsPointers are the Git LFS pointer files, standing for files stored
+	// out of the repository.
+	lfsPointers []string
+}
+
+func (s *specialFiles) empty() bool {
+	return len(s.symlinks) == 0 && len(s.submodules) == 0 && len(s.lfsPointers) == 0
+}
+
+func (s *specialFiles) addSymlink(file, relativePath string) {
+	target, err := os.Readlink(file)
+	if err != nil {
+		target = "?"
+	}
+
+	s.symlinks = append(s.symlinks, relativePath)
+	s.targets = append(s.targets, target)
+}
+
+// submodules tells the submodules of a walk by their directory.
+type submodules map[string]bool
+
+// newSubmodules returns the submodules declared in the .gitmodules file of
+// root, if any. Initialized submodules are found by their .git file anyway,
+// see is.
+func newSubmodules(root string) (submodules, error) {
+	f, err := os.Open(filepath.Join(root, ".gitmodules"))
+	if os.IsNotExist(err) {
+		return submodules{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	paths := submodules{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		key, value, ok := cutKeyValue(s.Text())
+		if ok && key == "path" {
+			paths[filepath.Clean(filepath.FromSlash(value))] = true
+		}
+	}
+
+	return paths, s.Err()
+}
+
+// cutKeyValue returns the key and the value of a "key = value" line of a git config file.
+func cutKeyValue(line string) (key, value string, ok bool) {
+	i := strings.IndexByte(line, '=')
+	if i < 0 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+}
+
+// is tells whether a directory, whose path relative to root has no
+// trailing slash, is a submodule: it is declared in .gitmodules, or it has
+// a .git of its own, as a gitlink file or a nested repository.
+func (s submodules) is(dir, relativePath string) bool {
+	if s[relativePath] {
+		return true
+	}
+
+	_, err := os.Lstat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// printSpecialFiles prints the special files of a walk, if any, as
+// printBreakDown does, with the target of every symbolic link.
+func printSpecialFiles(w io.Writer, s *specialFiles) {
+	if len(s.symlinks) > 0 {
+		fmt.Fprintln(w, "Symlinks")
+		for i, link := range s.symlinks {
+			fmt.Fprintf(w, "%s -> %s\n", filepath.ToSlash(link), s.targets[i])
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(s.submodules) > 0 {
+		fmt.Fprintln(w, "Submodules")
+		for _, dir := range s.submodules {
+			fmt.Fprintln(w, filepath.ToSlash(dir))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(s.lfsPointers) > 0 {
+		fmt.Fprintln(w, "Git LFS pointers")
+		for _, file := range s.lfsPointers {
+			fmt.Fprintln(w, filepath.ToSlash(file))
+		}
+		fmt.Fprintln(w)
+	}
+}
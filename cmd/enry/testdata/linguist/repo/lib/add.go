@@ -0,0 +1,6 @@
+package lib
+
+// Add returns the sum of a and b.
+func Add(a, b int) int {
+	return a + b
+}
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+// walkOptions select the files detectDir detects the language of.
+type walkOptions struct {
+	// limit is the number of bytes of the files read, 0 to read them whole.
+	limit int64
+	// countLines streams the files to their end to count their lines, which
+	// the records otherwise leave at 0.
+	countLines bool
+	// allLangs reports all the languages, instead of only programming and markup ones.
+	allLangs bool
+	// exclude leaves out the paths matching them, and include, if any, the
+	// files matching none of them, as .gitignore patterns relative to root.
+	exclude, include []ignorePattern
+	// gitignore leaves out the paths ignored by .gitignore and .ignore files.
+	gitignore        bool
+	includeVendor    bool
+	includeDocs      bool
+	includeGenerated bool
+	// jobs is the number of files read and detected in parallel, 0 for
+	// runtime.GOMAXPROCS(0). The results are in the order of the walk whatever
+	// the number.
+	jobs int
+	// progress, if not nil, counts the files detected.
+	progress *progress
+	// special, if not nil, collects the symbolic links, submodules and Git
+	// LFS pointers, which are left out of the analysis whether or not it is set.
+	special *specialFiles
+}
+
+// walkFlags are the flags selecting the files of a walk, shared by enry and
+// enry badge.
+type walkFlags struct {
+	// limit is the -limit in KB, see tokenizerOptions.
+	limit            *int
+	exclude, include patternsFlag
+	gitignore        *bool
+	includeVendor    *bool
+	includeDocs      *bool
+	includeGenerated *bool
+	jobs             *int
+}
+
+// newWalkFlags defines the walk flags in flags.
+func newWalkFlags(flags *flag.FlagSet) *walkFlags {
+	f := &walkFlags{}
+	f.limit = flags.Int("limit", 0, "Analyse N KB of the file, its head unless -sample says otherwise (0, the default, means the 100 KB the classifier tokenizes, -1 means no limit)")
+	flags.Var(&f.exclude, "exclude", "Leave out the paths matching a .gitignore pattern, relative to <path>. Can be repeated")
+	flags.Var(&f.include, "include", "Only analyse the files matching a .gitignore pattern, relative to <path>, or in a directory matching it. Can be repeated")
+	f.gitignore = flags.Bool("gitignore", false, "Leave out the paths ignored by .gitignore and .ignore files, and .git/info/exclude")
+	f.includeVendor = flags.Bool("include-vendor", false, "Analyse vendored files too")
+	f.includeDocs = flags.Bool("include-docs", false, "Analyse documentation files too")
+	f.includeGenerated = flags.Bool("include-generated", false, "Analyse generated files too, e.g. minified files and lock files")
+	f.jobs = flags.Int("j", 0, "Read and detect N files in parallel (0 means the number of CPUs)")
+	return f
+}
+
+// options returns the walkOptions set by the flags, but the limit, which
+// depends on the sampling of the classifier, see tokenizerOptions.
+func (f *walkFlags) options() walkOptions {
+	return walkOptions{
+		exclude:          parseIgnorePatterns(f.exclude),
+		include:          parseIgnorePatterns(f.include),
+		gitignore:        *f.gitignore,
+		includeVendor:    *f.includeVendor,
+		includeDocs:      *f.includeDocs,
+		includeGenerated: *f.includeGenerated,
+		jobs:             *f.jobs,
+	}
+}
+
+// skip tells whether a path relative to root, with a trailing slash if it is
+// a directory, is left out of the analysis.
+func (o walkOptions) skip(ig *ignorer, relativePath string, isDir bool) bool {
+	if enry.IsDotFile(relativePath) || enry.IsConfiguration(relativePath) ||
+		(!o.includeVendor && enry.IsVendor(relativePath)) ||
+		(!o.includeDocs && enry.IsDocumentation(relativePath)) {
+		return true
+	}
+
+	slashPath := strings.TrimSuffix(filepath.ToSlash(relativePath), "/")
+	if ig != nil && ig.ignored(slashPath, isDir) {
+		return true
+	}
+
+	if excluded, _ := matchPatterns(o.exclude, slashPath, isDir); excluded {
+		return true
+	}
+
+	return !isDir && len(o.include) > 0 && !o.included(slashPath)
+}
+
+// skipListed is the same as skip for a file given by path instead of
+// found by the walk: its directories are checked first, from the outermost
+// one, loading their ignore files as the walk does.
+func (o walkOptions) skipListed(ig *ignorer, relativePath string) (bool, error) {
+	dirs := strings.Split(filepath.ToSlash(relativePath), "/")
+	for i := 1; i < len(dirs); i++ {
+		dir := strings.Join(dirs[:i], "/")
+		if o.skip(ig, filepath.FromSlash(dir)+"/", true) {
+			return true, nil
+		}
+
+		if ig != nil && !ig.loaded(dir) {
+			if err := ig.load(dir); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return o.skip(ig, relativePath, false), nil
+}
+
+// included tells whether a file or any of its directories matches the include patterns.
+func (o walkOptions) included(file string) bool {
+	for p, isDir := file, false; p != "."; p, isDir = path.Dir(p), true {
+		if included, _ := matchPatterns(o.include, p, isDir); included {
+			return true
+		}
+	}
+	return false
+}
+
+// detectDir walks root and detects the language of every file in it
+// selected by opts, returning the records of the ones with a language to
+// report, their paths relative to root, in the order of the walk.
+// Generated files, see enry.IsGenerated, are returned apart, unless
+// opts.includeGenerated is set.
+func detectDir(detector *enry.Detector, root string, opts walkOptions) (results, generated []fileRecord, err error) {
+	var ig *ignorer
+	if opts.gitignore {
+		if ig, err = newIgnorer(root); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	subs, err := newSubmodules(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return detectFiles(detector, opts, func(add func(file, relativePath string) error) error {
+		return filepath.Walk(root, func(file string, f os.FileInfo, err error) error {
+			if err != nil {
+				log.Println(err)
+				return filepath.SkipDir
+			}
+
+			isSymlink := f.Mode()&os.ModeSymlink != 0
+			if !f.Mode().IsDir() && !f.Mode().IsRegular() && !isSymlink {
+				return nil
+			}
+
+			relativePath, err := filepath.Rel(root, file)
+			if err != nil {
+				log.Println(err)
+				return nil
+			}
+
+			if relativePath == "." {
+				return nil
+			}
+
+			if f.IsDir() {
+				relativePath = relativePath + "/"
+			}
+
+			if opts.skip(ig, relativePath, f.IsDir()) {
+				if f.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if isSymlink {
+				if opts.special != nil {
+					opts.special.addSymlink(file, relativePath)
+				}
+				return nil
+			}
+
+			if f.IsDir() {
+				dir := strings.TrimSuffix(relativePath, "/")
+				if subs.is(file, dir) {
+					if opts.special != nil {
+						opts.special.submodules = append(opts.special.submodules, dir)
+					}
+					return filepath.SkipDir
+				}
+
+				if ig != nil {
+					return ig.load(filepath.ToSlash(dir))
+				}
+				return nil
+			}
+
+			return add(file, relativePath)
+		})
+	})
+}
+
+// detectList is the same as detectDir, for the files listed in list, one
+// path per line, "-" to read them from stdin. Relative paths are relative
+// to root, and so are the paths of the results.
+func detectList(detector *enry.Detector, root, list string, opts walkOptions) (results, generated []fileRecord, err error) {
+	r := os.Stdin
+	if list != "-" {
+		if r, err = os.Open(list); err != nil {
+			return nil, nil, err
+		}
+		defer r.Close()
+	}
+
+	var ig *ignorer
+	if opts.gitignore {
+		if ig, err = newIgnorer(root); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return detectFiles(detector, opts, func(add func(file, relativePath string) error) error {
+		s := bufio.NewScanner(r)
+		for s.Scan() {
+			file := strings.TrimSpace(s.Text())
+			if file == "" {
+				continue
+			}
+
+			var err error
+			relativePath := filepath.Clean(file)
+			if filepath.IsAbs(file) {
+				if relativePath, err = filepath.Rel(root, file); err != nil {
+					log.Println(err)
+					continue
+				}
+			} else {
+				file = filepath.Join(root, file)
+			}
+
+			skip, err := opts.skipListed(ig, relativePath)
+			if err != nil {
+				return err
+			}
+			if skip {
+				continue
+			}
+
+			// git ls-files lists symbolic links, and submodules as their directory
+			f, err := os.Lstat(file)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			switch {
+			case f.Mode()&os.ModeSymlink != 0:
+				if opts.special != nil {
+					opts.special.addSymlink(file, relativePath)
+				}
+				continue
+			case f.IsDir():
+				if opts.special != nil {
+					opts.special.submodules = append(opts.special.submodules, relativePath)
+				}
+				continue
+			case !f.Mode().IsRegular():
+				continue
+			}
+
+			if err := add(file, relativePath); err != nil {
+				return err
+			}
+		}
+		return s.Err()
+	})
+}
+
+// detectFiles detects the language of the files produce adds, by their path
+// and their path relative to the root they are reported by. See detectDir.
+func detectFiles(detector *enry.Detector, opts walkOptions, produce func(add func(file, relativePath string) error) error) (results, generated []fileRecord, err error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// stats and isLFSPointer hold the stats of the files and the paths of
+	// the Git LFS pointers, found when their content is loaded by the
+	// detector, so that it is read only once
+	var stats, isLFSPointer sync.Map
+	files := make(chan enry.Item)
+	produceErr := make(chan error, 1)
+	go func() {
+		defer close(files)
+		produceErr <- produce(func(file, relativePath string) error {
+			item := enry.Item{
+				Path: relativePath,
+				Load: func() ([]byte, error) {
+					content, s, err := loadFile(file, opts.limit, opts.countLines)
+					if err != nil {
+						return nil, err
+					}
+
+					if enry.IsLFSPointer(content) {
+						isLFSPointer.Store(relativePath, true)
+					} else {
+						s.generated = enry.IsGenerated(filepath.ToSlash(relativePath), content)
+					}
+					stats.Store(relativePath, s)
+					return content, nil
+				},
+			}
+
+			select {
+			case files <- item:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	items := enry.ItemsFunc(func() (enry.Item, error) {
+		item, ok := <-files
+		if !ok {
+			return enry.Item{}, io.EOF
+		}
+		return item, nil
+	})
+
+	err = detector.DetectEach(ctx, items, enry.BatchOptions{Workers: opts.jobs}, func(r enry.Result) error {
+		opts.progress.add()
+		if r.Err != nil {
+			log.Println(r.Err)
+			return nil
+		}
+
+		if _, ok := isLFSPointer.Load(r.Path); ok {
+			if opts.special != nil {
+				opts.special.lfsPointers = append(opts.special.lfsPointers, r.Path)
+			}
+			return nil
+		}
+
+		if r.Language == enry.OtherLanguage {
+			return nil
+		}
+
+		// If we are not asked to display all, do as
+		// https://github.com/github/linguist/blob/bf95666fc15e49d556f2def4d0a85338423c25f3/lib/linguist/blob_helper.rb#L382
+		if !opts.allLangs &&
+			enry.GetLanguageType(r.Language) != enry.Programming &&
+			enry.GetLanguageType(r.Language) != enry.Markup {
+			return nil
+		}
+
+		s, _ := stats.Load(r.Path)
+		record := newFileRecord(r, s.(fileStats))
+		if record.Generated && !opts.includeGenerated {
+			generated = append(generated, record)
+			return nil
+		}
+
+		results = append(results, record)
+		return nil
+	})
+
+	cancel()
+	if produceErr := <-produceErr; err == nil {
+		err = produceErr
+	}
+
+	return results, generated, err
+}
+
+// fileStats is what is reported about the whole of a file, besides its
+// language, found while it is loaded to be detected.
+type fileStats struct {
+	bytes           int64
+	lines, nonBlank int
+	generated       bool
+}
+
+// loadFile returns the first limit bytes of a file, all of it if limit is 0,
+// and the stats of the whole file, streaming the rest of it to count its
+// lines if countLines is set. Whether the file is generated is left to the
+// caller.
+func loadFile(file string, limit int64, countLines bool) ([]byte, fileStats, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fileStats{}, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fileStats{}, err
+	}
+
+	var r io.Reader = f
+	if limit > 0 {
+		r = io.LimitReader(f, limit)
+	}
+
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fileStats{}, err
+	}
+
+	stats := fileStats{bytes: fi.Size()}
+	if countLines {
+		stats.lines, stats.nonBlank, err = countFileLines(io.MultiReader(bytes.NewReader(content), f))
+		if err != nil {
+			return nil, fileStats{}, err
+		}
+	}
+	return content, stats, nil
+}
+
+// groupByLanguage returns the paths of the records grouped by language.
+func groupByLanguage(records []fileRecord) map[string][]string {
+	out := make(map[string][]string)
+	for _, r := range records {
+		out[r.Language] = append(out[r.Language], r.Path)
+	}
+	return out
+}
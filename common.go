@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-enry/go-enry/v2/data"
 	"github.com/go-enry/go-enry/v2/regex"
+	"github.com/go-enry/go-enry/v2/tokenizer"
 )
 
 // OtherLanguage is used as a zero value when a function can not return a specific language.
@@ -31,11 +32,7 @@ var DefaultStrategies = []Strategy{
 }
 
 // defaultClassifier is a Naive Bayes classifier trained on Linguist samples.
-var defaultClassifier classifier = &naiveBayes{
-	languagesLogProbabilities: data.LanguagesLogProbabilities,
-	tokensLogProbabilities:    data.TokensLogProbabilities,
-	tokensTotal:               data.TokensTotal,
-}
+var defaultClassifier classifier = newNaiveBayes(tokenizer.Options{})
 
 // GetLanguage applies a sequence of strategies based on the given filename and content
 // to find out the most probable language to return.
@@ -123,12 +120,16 @@ func getFirstLanguageAndSafe(languages []string) (language string, safe bool) {
 // At least one of arguments should be set. If content is missing, language detection will be based on the filename.
 // The function won't read the file, given an empty content.
 func GetLanguages(filename string, content []byte) []string {
+	return getLanguagesByStrategies(DefaultStrategies, filename, content)
+}
+
+func getLanguagesByStrategies(strategies []Strategy, filename string, content []byte) []string {
 	if IsBinary(content) {
 		return nil
 	}
 
 	var languages []string
-	for _, strategy := range DefaultStrategies {
+	for _, strategy := range strategies {
 		candidates := strategy(filename, content, languages)
 		// No candidates, continue to next strategy without updating languages
 		if len(candidates) == 0 {
@@ -468,13 +469,17 @@ func getDotIndexes(filename string) []int {
 // GetLanguagesByContent returns a slice of languages for the given content.
 // It is a Strategy that uses content-based regexp heuristics and a filename extension.
 func GetLanguagesByContent(filename string, content []byte, _ []string) []string {
+	return getLanguagesByHeuristics(data.ContentHeuristics, filename, content)
+}
+
+func getLanguagesByHeuristics(heuristics map[string]*data.Heuristics, filename string, content []byte) []string {
 	if filename == "" {
 		return nil
 	}
 
 	ext := strings.ToLower(filepath.Ext(filename))
 
-	heuristic, ok := data.ContentHeuristics[ext]
+	heuristic, ok := heuristics[ext]
 	if !ok {
 		return nil
 	}
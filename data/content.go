@@ -3,10 +3,7 @@
 
 package data
 
-import (
-	"github.com/go-enry/go-enry/v2/data/rule"
-	"github.com/go-enry/go-enry/v2/regex"
-)
+import "github.com/go-enry/go-enry/v2/data/rule"
 
 var ContentHeuristics = map[string]*Heuristics{
 	".1": &Heuristics{
@@ -14,31 +11,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".1in": &Heuristics{
@@ -46,26 +43,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -77,26 +74,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -108,26 +105,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -139,31 +136,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".3": &Heuristics{
@@ -171,31 +168,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".3in": &Heuristics{
@@ -203,26 +200,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -234,26 +231,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -265,26 +262,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -296,26 +293,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -327,26 +324,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -358,26 +355,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -389,31 +386,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".5": &Heuristics{
@@ -421,31 +418,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".6": &Heuristics{
@@ -453,31 +450,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".7": &Heuristics{
@@ -485,31 +482,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".8": &Heuristics{
@@ -517,31 +514,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".9": &Heuristics{
@@ -549,31 +546,31 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
+			rule.Multiline(`^\.(?:[A-Za-z]{2}(?:\s|$)|\\")`),
 		),
 	},
 	".al": &Heuristics{
@@ -581,7 +578,7 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("AL"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`\b(?i:(CODEUNIT|PAGE|PAGEEXTENSION|PAGECUSTOMIZATION|DOTNET|ENUM|ENUMEXTENSION|VALUE|QUERY|REPORT|TABLE|TABLEEXTENSION|XMLPORT|PROFILE|CONTROLADDIN|REPORTEXTENSION|INTERFACE|PERMISSIONSET|PERMISSIONSETEXTENSION|ENTITLEMENT))\b`),
+				rule.Multiline(`\b(?i:(CODEUNIT|PAGE|PAGEEXTENSION|PAGECUSTOMIZATION|DOTNET|ENUM|ENUMEXTENSION|VALUE|QUERY|REPORT|TABLE|TABLEEXTENSION|XMLPORT|PROFILE|CONTROLADDIN|REPORTEXTENSION|INTERFACE|PERMISSIONSET|PERMISSIONSETEXTENSION|ENTITLEMENT))\b`),
 			),
 		),
 		rule.Always(
@@ -591,39 +588,39 @@ var ContentHeuristics = map[string]*Heuristics{
 	".app": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Erlang"),
-			regex.MustCompileMultiline(`^\{\s*(?:application|'application')\s*,\s*(?:[a-z]+[\w@]*|'[^']+')\s*,\s*\[(?:.|[\r\n])*\]\s*\}\.[ \t]*$`),
+			rule.Multiline(`^\{\s*(?:application|'application')\s*,\s*(?:[a-z]+[\w@]*|'[^']+')\s*,\s*\[(?:.|[\r\n])*\]\s*\}\.[ \t]*$`),
 		),
 	},
 	".as": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("ActionScript"),
-			regex.MustCompileRuby(`^\s*(?:package(?:\s+[\w.]+)?\s+(?:\{|$)|import\s+[\w.*]+\s*;|(?=.*?(?:intrinsic|extends))(intrinsic\s+)?class\s+[\w<>.]+(?:\s+extends\s+[\w<>.]+)?|(?:(?:public|protected|private|static)\s+)*(?:(?:var|const|local)\s+\w+\s*:\s*[\w<>.]+(?:\s*=.*)?\s*;|function\s+\w+\s*\((?:\s*\w+\s*:\s*[\w<>.]+\s*(,\s*\w+\s*:\s*[\w<>.]+\s*)*)?\)))`),
+			rule.Ruby(`^\s*(?:package(?:\s+[\w.]+)?\s+(?:\{|$)|import\s+[\w.*]+\s*;|(?=.*?(?:intrinsic|extends))(intrinsic\s+)?class\s+[\w<>.]+(?:\s+extends\s+[\w<>.]+)?|(?:(?:public|protected|private|static)\s+)*(?:(?:var|const|local)\s+\w+\s*:\s*[\w<>.]+(?:\s*=.*)?\s*;|function\s+\w+\s*\((?:\s*\w+\s*:\s*[\w<>.]+\s*(,\s*\w+\s*:\s*[\w<>.]+\s*)*)?\)))`),
 		),
 	},
 	".asc": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Public Key"),
-			regex.MustCompileMultiline(`^(----[- ]BEGIN|ssh-(rsa|dss)) `),
+			rule.Multiline(`^(----[- ]BEGIN|ssh-(rsa|dss)) `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("AsciiDoc"),
-			regex.MustCompileMultiline(`^[=-]+\s|\{\{[A-Za-z]`),
+			rule.Multiline(`^[=-]+\s|\{\{[A-Za-z]`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("AGS Script"),
-			regex.MustCompileMultiline(`^(\/\/.+|((import|export)\s+)?(function|int|float|char)\s+((room|repeatedly|on|game)_)?([A-Za-z]+[A-Za-z_0-9]+)\s*[;\(])`),
+			rule.Multiline(`^(\/\/.+|((import|export)\s+)?(function|int|float|char)\s+((room|repeatedly|on|game)_)?([A-Za-z]+[A-Za-z_0-9]+)\s*[;\(])`),
 		),
 	},
 	".asm": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Motorola 68K Assembly"),
-			regex.MustCompileMultiline(`(?im)\bmoveq(?:\.l)?\s+#(?:\$-?[0-9a-f]{1,3}|%[0-1]{1,8}|-?[0-9]{1,3}),\s*d[0-7]\b|(?im)^\s*move(?:\.[bwl])?\s+(?:sr|usp),\s*[^\s]+|(?im)^\s*move\.[bwl]\s+.*\b[ad]\d|(?im)^\s*movem\.[bwl]\b|(?im)^\s*move[mp](?:\.[wl])?\b|(?im)^\s*btst\b|(?im)^\s*dbra\b`),
+			rule.Multiline(`(?im)\bmoveq(?:\.l)?\s+#(?:\$-?[0-9a-f]{1,3}|%[0-1]{1,8}|-?[0-9]{1,3}),\s*d[0-7]\b|(?im)^\s*move(?:\.[bwl])?\s+(?:sr|usp),\s*[^\s]+|(?im)^\s*move\.[bwl]\s+.*\b[ad]\d|(?im)^\s*movem\.[bwl]\b|(?im)^\s*move[mp](?:\.[wl])?\b|(?im)^\s*btst\b|(?im)^\s*dbra\b`),
 		),
 	},
 	".asy": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("LTspice Symbol"),
-			regex.MustCompileMultiline(`^SymbolType[ \t]`),
+			rule.Multiline(`^SymbolType[ \t]`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Asymptote"),
@@ -632,109 +629,109 @@ var ContentHeuristics = map[string]*Heuristics{
 	".bas": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("B4X"),
-			regex.MustCompileMultiline(`\A\W{0,3}(?:.*(?:\r?\n|\r)){0,9}B4(?:J|A|R|i)=true`),
+			rule.Multiline(`\A\W{0,3}(?:.*(?:\r?\n|\r)){0,9}B4(?:J|A|R|i)=true`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("FreeBASIC"),
-			regex.MustCompileMultiline(`(?i)^[ \t]*#(?:define|endif|endmacro|ifn?def|include|lang|macro|pragma)(?:$|\s)|(?i)^[ \t]*dim( shared)? [a-z_][a-z0-9_]* as [a-z_][a-z0-9_]* ptr|(?i)^[ \t]*dim( shared)? as [a-z_][a-z0-9_]* [a-z_][a-z0-9_]*`),
+			rule.Multiline(`(?i)^[ \t]*#(?:define|endif|endmacro|ifn?def|include|lang|macro|pragma)(?:$|\s)|(?i)^[ \t]*dim( shared)? [a-z_][a-z0-9_]* as [a-z_][a-z0-9_]* ptr|(?i)^[ \t]*dim( shared)? as [a-z_][a-z0-9_]* [a-z_][a-z0-9_]*`),
 		),
 		rule.And(
 			rule.MatchingLanguages("FreeBASIC"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`(?i)^[ \t]*return `),
+				rule.Multiline(`(?i)^[ \t]*return `),
 			),
 			rule.Not(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`(?i)[ \t]*gosub `),
+				rule.Multiline(`(?i)[ \t]*gosub `),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("BASIC"),
-			regex.MustCompileMultiline(`\A\s*\d`),
+			rule.Multiline(`\A\s*\d`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("QuickBASIC"),
-			regex.MustCompileMultiline(`^[ ]*(CONST|DIM|REDIM|DEFINT|PRINT|DECLARE (SUB|FUNCTION)|FUNCTION|SUB) |(#|$)lang:?\s*"?qb"?|(?i)'\$INCLUDE:|(?i)^[ ]*CLS[ ]*('|:|\r|\n)|(?i)^[ ]*OPTION _EXPLICIT|(?i)^[ ]*DIM SHARED |(?i)^[ ]*PRINT "|(?i) As _(Byte|Offset|MEM)|(?i)^[ ]*_(DISPLAY|DEST|CONSOLE|SOURCE|FREEIMAGE|PALETTECOLOR|PRINTSTRING|LOADFONT|PUTIMAGE)|(?i)^[ ]*_(TITLE|PLAYMOD) "|(?i)^[ ]*_(LIMIT|SCREEN|DELAY) \.?\d+|(?i)\b_(MOUSEBUTTON|NEWIMAGE|KEYDOWN|WIDTH|HEIGHT)\(|(?i)^[ ]*\$(CONSOLE|CHECKING):|(?i)^[ ]*\$(FULLSCREEN|RESIZE|STATIC|DYNAMIC|NOPREFIX|SCREENSHOW|SCREENHIDE|EXEICON)\b`),
+			rule.Multiline(`^[ ]*(CONST|DIM|REDIM|DEFINT|PRINT|DECLARE (SUB|FUNCTION)|FUNCTION|SUB) |(#|$)lang:?\s*"?qb"?|(?i)'\$INCLUDE:|(?i)^[ ]*CLS[ ]*('|:|\r|\n)|(?i)^[ ]*OPTION _EXPLICIT|(?i)^[ ]*DIM SHARED |(?i)^[ ]*PRINT "|(?i) As _(Byte|Offset|MEM)|(?i)^[ ]*_(DISPLAY|DEST|CONSOLE|SOURCE|FREEIMAGE|PALETTECOLOR|PRINTSTRING|LOADFONT|PUTIMAGE)|(?i)^[ ]*_(TITLE|PLAYMOD) "|(?i)^[ ]*_(LIMIT|SCREEN|DELAY) \.?\d+|(?i)\b_(MOUSEBUTTON|NEWIMAGE|KEYDOWN|WIDTH|HEIGHT)\(|(?i)^[ ]*\$(CONSOLE|CHECKING):|(?i)^[ ]*\$(FULLSCREEN|RESIZE|STATIC|DYNAMIC|NOPREFIX|SCREENSHOW|SCREENHIDE|EXEICON)\b`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("VBA"),
-			regex.MustCompileMultiline(`\b(?:VBA|[vV]ba)(?:\b|[0-9A-Z_])|^[ ]*(?:Public|Private)? Declare PtrSafe (?:Sub|Function)\b|^[ ]*#If Win64\b|^[ ]*(?:Dim|Const) [0-9a-zA-Z_]*[ ]*As Long(?:Ptr|Long)\b|^[ ]*Option (?:Private Module|Compare Database)\b|(?: |\()(?:Access|Excel|Outlook|PowerPoint|Visio|Word|VBIDE)\.\w|\b(?:(?:Active)?VBProjects?|VBComponents?|Application\.(?:VBE|ScreenUpdating))\b|\b(?:ThisDrawing|AcadObject|Active(?:Explorer|Inspector|Window\.Presentation|Presentation|Document)|Selection\.(?:Document|Find|Paragraphs|Range))\b|\b(?:(?:This|Active)?Workbooks?|Worksheets?|Active(?:Sheet|Chart|Cell)|WorksheetFunction)\b|\b(?:Range\(".*|Cells\([0-9a-zA-Z_]*, (?:[0-9a-zA-Z_]*|"[a-zA-Z]{1,3}"))\)`),
+			rule.Multiline(`\b(?:VBA|[vV]ba)(?:\b|[0-9A-Z_])|^[ ]*(?:Public|Private)? Declare PtrSafe (?:Sub|Function)\b|^[ ]*#If Win64\b|^[ ]*(?:Dim|Const) [0-9a-zA-Z_]*[ ]*As Long(?:Ptr|Long)\b|^[ ]*Option (?:Private Module|Compare Database)\b|(?: |\()(?:Access|Excel|Outlook|PowerPoint|Visio|Word|VBIDE)\.\w|\b(?:(?:Active)?VBProjects?|VBComponents?|Application\.(?:VBE|ScreenUpdating))\b|\b(?:ThisDrawing|AcadObject|Active(?:Explorer|Inspector|Window\.Presentation|Presentation|Document)|Selection\.(?:Document|Find|Paragraphs|Range))\b|\b(?:(?:This|Active)?Workbooks?|Worksheets?|Active(?:Sheet|Chart|Cell)|WorksheetFunction)\b|\b(?:Range\(".*|Cells\([0-9a-zA-Z_]*, (?:[0-9a-zA-Z_]*|"[a-zA-Z]{1,3}"))\)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Visual Basic 6.0"),
-			regex.MustCompileMultiline(`^[ ]*Attribute VB_Name = `),
+			rule.Multiline(`^[ ]*Attribute VB_Name = `),
 		),
 	},
 	".bb": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("BlitzBasic"),
-			regex.MustCompileMultiline(`(<^\s*; |End Function)`),
+			rule.Multiline(`(<^\s*; |End Function)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("BitBake"),
-			regex.MustCompileMultiline(`^(# |include|require|inherit)\b`),
+			rule.Multiline(`^(# |include|require|inherit)\b`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Clojure"),
-			regex.MustCompileMultiline(`\((def|defn|defmacro|let)\s`),
+			rule.Multiline(`\((def|defn|defmacro|let)\s`),
 		),
 	},
 	".bf": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Beef"),
-			regex.MustCompileMultiline(`(?-m)^\s*using\s+(System|Beefy)(\.(.*))?;\s*$`),
+			rule.Multiline(`(?-m)^\s*using\s+(System|Beefy)(\.(.*))?;\s*$`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("HyPhy"),
-			regex.MustCompileMultiline(`(?-m)^\s*#include\s+".*";\s*$|\sfprintf\s*\(`),
+			rule.Multiline(`(?-m)^\s*#include\s+".*";\s*$|\sfprintf\s*\(`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Brainfuck"),
-			regex.MustCompileMultiline(`(>\+>|>\+<)`),
+			rule.Multiline(`(>\+>|>\+<)`),
 		),
 	},
 	".bi": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("FreeBASIC"),
-			regex.MustCompileMultiline(`(?i)^[ \t]*#(?:define|endif|endmacro|ifn?def|include|lang|macro|pragma)(?:$|\s)|(?i)^[ \t]*dim( shared)? [a-z_][a-z0-9_]* as [a-z_][a-z0-9_]* ptr|(?i)^[ \t]*dim( shared)? as [a-z_][a-z0-9_]* [a-z_][a-z0-9_]*`),
+			rule.Multiline(`(?i)^[ \t]*#(?:define|endif|endmacro|ifn?def|include|lang|macro|pragma)(?:$|\s)|(?i)^[ \t]*dim( shared)? [a-z_][a-z0-9_]* as [a-z_][a-z0-9_]* ptr|(?i)^[ \t]*dim( shared)? as [a-z_][a-z0-9_]* [a-z_][a-z0-9_]*`),
 		),
 		rule.And(
 			rule.MatchingLanguages("FreeBASIC"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`(?i)^[ \t]*return `),
+				rule.Multiline(`(?i)^[ \t]*return `),
 			),
 			rule.Not(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`(?i)[ \t]*gosub `),
+				rule.Multiline(`(?i)[ \t]*gosub `),
 			),
 		),
 	},
 	".bs": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Bikeshed"),
-			regex.MustCompileRuby(`^(?i:<pre\s+class)\s*=\s*('|\"|\b)metadata\b\1[^>\r\n]*>`),
+			rule.Ruby(`^(?i:<pre\s+class)\s*=\s*('|\"|\b)metadata\b\1[^>\r\n]*>`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("BrighterScript"),
-			regex.MustCompileRuby(`(?i:^\s*(?=^sub\s)(?:sub\s*\w+\(.*?\))|(?::\s*sub\(.*?\))$)|(?i:^\s*(end\ssub)$)|(?i:^\s*(?=^function\s)(?:function\s*\w+\(.*?\)\s*as\s*\w*)|(?::\s*function\(.*?\)\s*as\s*\w*)$)|(?i:^\s*(end\sfunction)$)`),
+			rule.Ruby(`(?i:^\s*(?=^sub\s)(?:sub\s*\w+\(.*?\))|(?::\s*sub\(.*?\))$)|(?i:^\s*(end\ssub)$)|(?i:^\s*(?=^function\s)(?:function\s*\w+\(.*?\)\s*as\s*\w*)|(?::\s*function\(.*?\)\s*as\s*\w*)$)|(?i:^\s*(end\sfunction)$)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Bluespec BH"),
-			regex.MustCompileMultiline(`^package\s+[A-Za-z_][A-Za-z0-9_']*(?:\s*\(|\s+where)`),
+			rule.Multiline(`^package\s+[A-Za-z_][A-Za-z0-9_']*(?:\s*\(|\s+where)`),
 		),
 	},
 	".builds": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`^(\s*)(?i:<Project|<Import|<Property|<?xml|xmlns)`),
+			rule.Multiline(`^(\s*)(?i:<Project|<Import|<Property|<?xml|xmlns)`),
 		),
 	},
 	".cairo": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Cairo Zero"),
-			regex.MustCompileMultiline(`(^(\s*)%lang(\s+)([A-Za-z0-9_]+))|(^(\s*)%builtins(\s+)([A-Za-z0-9_]+\s*)*$)|(^(\s*)from(\s+)starkware\.(cairo|starknet)\.([A-Za-z0-9_.\s]+?)import)|(,\s*ap\+\+;$)|(;\s*ap\+\+$)`),
+			rule.Multiline(`(^(\s*)%lang(\s+)([A-Za-z0-9_]+))|(^(\s*)%builtins(\s+)([A-Za-z0-9_]+\s*)*$)|(^(\s*)from(\s+)starkware\.(cairo|starknet)\.([A-Za-z0-9_.\s]+?)import)|(,\s*ap\+\+;$)|(;\s*ap\+\+$)`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Cairo"),
@@ -743,21 +740,21 @@ var ContentHeuristics = map[string]*Heuristics{
 	".ch": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("xBase"),
-			regex.MustCompileMultiline(`^\s*#\s*(?i:if|ifdef|ifndef|define|command|xcommand|translate|xtranslate|include|pragma|undef)\b`),
+			rule.Multiline(`^\s*#\s*(?i:if|ifdef|ifndef|define|command|xcommand|translate|xtranslate|include|pragma|undef)\b`),
 		),
 	},
 	".cl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Common Lisp"),
-			regex.MustCompileMultiline(`^\s*\((?i:defun|in-package|defpackage) `),
+			rule.Multiline(`^\s*\((?i:defun|in-package|defpackage) `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Cool"),
-			regex.MustCompileMultiline(`^class`),
+			rule.Multiline(`^class`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("OpenCL"),
-			regex.MustCompileMultiline(`\/\* |\/\/ |^\}`),
+			rule.Multiline(`\/\* |\/\/ |^\}`),
 		),
 	},
 	".cls": &Heuristics{
@@ -765,158 +762,158 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Visual Basic 6.0"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[ ]*VERSION [0-9]\.[0-9] CLASS`),
+				rule.Multiline(`^[ ]*VERSION [0-9]\.[0-9] CLASS`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^\s*BEGIN(?:\r?\n|\r)\s*MultiUse\s*=.*(?:\r?\n|\r)\s*Persistable\s*=`),
+				rule.Multiline(`^\s*BEGIN(?:\r?\n|\r)\s*MultiUse\s*=.*(?:\r?\n|\r)\s*Persistable\s*=`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("VBA"),
-			regex.MustCompileMultiline(`^[ ]*VERSION [0-9]\.[0-9] CLASS`),
+			rule.Multiline(`^[ ]*VERSION [0-9]\.[0-9] CLASS`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("TeX"),
-			regex.MustCompileMultiline(`^\s*\\(?:NeedsTeXFormat|ProvidesClass)\{`),
+			rule.Multiline(`^\s*\\(?:NeedsTeXFormat|ProvidesClass)\{`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("ObjectScript"),
-			regex.MustCompileMultiline(`^Class\s`),
+			rule.Multiline(`^Class\s`),
 		),
 	},
 	".cmp": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Gerber Image"),
-			regex.MustCompileMultiline(`^[DGMT][0-9]{2}\*(?:\r?\n|\r)`),
+			rule.Multiline(`^[DGMT][0-9]{2}\*(?:\r?\n|\r)`),
 		),
 	},
 	".cs": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Smalltalk"),
-			regex.MustCompileMultiline(`![\w\s]+methodsFor: `),
+			rule.Multiline(`![\w\s]+methodsFor: `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("C#"),
-			regex.MustCompileMultiline(`^\s*(using\s+[A-Z][\s\w.]+;|namespace\s*[\w\.]+\s*(\{|;)|\/\/)`),
+			rule.Multiline(`^\s*(using\s+[A-Z][\s\w.]+;|namespace\s*[\w\.]+\s*(\{|;)|\/\/)`),
 		),
 	},
 	".csc": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("GSC"),
-			regex.MustCompileRuby(`^\s*#\s*(?:using|insert|include|define|namespace)[ \t]+\w|^\s*(?>(?:autoexec|private)\s+){0,2}function\s+(?>(?:autoexec|private)\s+){0,2}\w+\s*\(|\b(?:level|self)[ \t]+thread[ \t]+(?:\[\[[ \t]*(?>\w+\.)*\w+[ \t]*\]\]|\w+)[ \t]*\([^\r\n\)]*\)[ \t]*;|^[ \t]*#[ \t]*(?:precache|using_animtree)[ \t]*\(`),
+			rule.Ruby(`^\s*#\s*(?:using|insert|include|define|namespace)[ \t]+\w|^\s*(?>(?:autoexec|private)\s+){0,2}function\s+(?>(?:autoexec|private)\s+){0,2}\w+\s*\(|\b(?:level|self)[ \t]+thread[ \t]+(?:\[\[[ \t]*(?>\w+\.)*\w+[ \t]*\]\]|\w+)[ \t]*\([^\r\n\)]*\)[ \t]*;|^[ \t]*#[ \t]*(?:precache|using_animtree)[ \t]*\(`),
 		),
 	},
 	".csl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`(?i:^\s*(<\?xml|xmlns))`),
+			rule.Multiline(`(?i:^\s*(<\?xml|xmlns))`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Kusto"),
-			regex.MustCompileMultiline(`(^\|\s*(where|extend|project|limit|summarize))|(^\.\w+)`),
+			rule.Multiline(`(^\|\s*(where|extend|project|limit|summarize))|(^\.\w+)`),
 		),
 	},
 	".d": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("D"),
-			regex.MustCompileMultiline(`^module\s+[\w.]*\s*;|import\s+[\w\s,.:]*;|\w+\s+\w+\s*\(.*\)(?:\(.*\))?\s*\{[^}]*\}|unittest\s*(?:\(.*\))?\s*\{[^}]*\}`),
+			rule.Multiline(`^module\s+[\w.]*\s*;|import\s+[\w\s,.:]*;|\w+\s+\w+\s*\(.*\)(?:\(.*\))?\s*\{[^}]*\}|unittest\s*(?:\(.*\))?\s*\{[^}]*\}`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("DTrace"),
-			regex.MustCompileMultiline(`^(\w+:\w*:\w*:\w*|BEGIN|END|provider\s+|(tick|profile)-\w+\s+\{[^}]*\}|#pragma\s+D\s+(option|attributes|depends_on)\s|#pragma\s+ident\s)`),
+			rule.Multiline(`^(\w+:\w*:\w*:\w*|BEGIN|END|provider\s+|(tick|profile)-\w+\s+\{[^}]*\}|#pragma\s+D\s+(option|attributes|depends_on)\s|#pragma\s+ident\s)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Makefile"),
-			regex.MustCompileMultiline(`([\/\\].*:\s+.*\s\\$|: \\$|^[ %]:|^[\w\s\/\\.]+\w+\.\w+\s*:\s+[\w\s\/\\.]+\w+\.\w+)`),
+			rule.Multiline(`([\/\\].*:\s+.*\s\\$|: \\$|^[ %]:|^[\w\s\/\\.]+\w+\.\w+\s*:\s+[\w\s\/\\.]+\w+\.\w+)`),
 		),
 	},
 	".dsp": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Microsoft Developer Studio Project"),
-			regex.MustCompileMultiline(`# Microsoft Developer Studio Generated Build File`),
+			rule.Multiline(`# Microsoft Developer Studio Generated Build File`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Faust"),
-			regex.MustCompileMultiline(`\bprocess\s*[(=]|\b(library|import)\s*\(\s*"|\bdeclare\s+(name|version|author|copyright|license)\s+"`),
+			rule.Multiline(`\bprocess\s*[(=]|\b(library|import)\s*\(\s*"|\bdeclare\s+(name|version|author|copyright|license)\s+"`),
 		),
 	},
 	".e": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("E"),
-			regex.MustCompileMultiline(`^\s*(def|var)\s+(.+):=|^\s*(def|to)\s+(\w+)(\(.+\))?\s+\{|^\s*(when)\s+(\(.+\))\s+->\s+\{`),
+			rule.Multiline(`^\s*(def|var)\s+(.+):=|^\s*(def|to)\s+(\w+)(\(.+\))?\s+\{|^\s*(when)\s+(\(.+\))\s+->\s+\{`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Eiffel"),
-			regex.MustCompileMultiline(`^\s*\w+\s*(?:,\s*\w+)*[:]\s*\w+\s|^\s*\w+\s*(?:\(\s*\w+[:][^)]+\))?(?:[:]\s*\w+)?(?:--.+\s+)*\s+(?:do|local)\s|^\s*(?:across|deferred|elseif|ensure|feature|from|inherit|inspect|invariant|note|once|require|undefine|variant|when)\s*$`),
+			rule.Multiline(`^\s*\w+\s*(?:,\s*\w+)*[:]\s*\w+\s|^\s*\w+\s*(?:\(\s*\w+[:][^)]+\))?(?:[:]\s*\w+)?(?:--.+\s+)*\s+(?:do|local)\s|^\s*(?:across|deferred|elseif|ensure|feature|from|inherit|inspect|invariant|note|once|require|undefine|variant|when)\s*$`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Euphoria"),
-			regex.MustCompileMultiline(`^\s*namespace\s|^\s*(?:public\s+)?include\s|^\s*(?:(?:public|export|global)\s+)?(?:atom|constant|enum|function|integer|object|procedure|sequence|type)\s`),
+			rule.Multiline(`^\s*namespace\s|^\s*(?:public\s+)?include\s|^\s*(?:(?:public|export|global)\s+)?(?:atom|constant|enum|function|integer|object|procedure|sequence|type)\s`),
 		),
 	},
 	".ecl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("ECLiPSe"),
-			regex.MustCompileMultiline(`^[^#]+:-`),
+			rule.Multiline(`^[^#]+:-`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("ECL"),
-			regex.MustCompileMultiline(`:=`),
+			rule.Multiline(`:=`),
 		),
 	},
 	".es": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Erlang"),
-			regex.MustCompileMultiline(`^\s*(?:%%|main\s*\(.*?\)\s*->)`),
+			rule.Multiline(`^\s*(?:%%|main\s*\(.*?\)\s*->)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("JavaScript"),
-			regex.MustCompileMultiline(`\/\/|["']use strict["']|export\s+default\s|\/\*(?:.|[\r\n])*?\*\/`),
+			rule.Multiline(`\/\/|["']use strict["']|export\s+default\s|\/\*(?:.|[\r\n])*?\*\/`),
 		),
 	},
 	".ex": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Elixir"),
-			regex.MustCompileMultiline(`^\s*@moduledoc\s|^\s*(?:cond|import|quote|unless)\s|^\s*def(?:exception|impl|macro|module|protocol)[(\s]`),
+			rule.Multiline(`^\s*@moduledoc\s|^\s*(?:cond|import|quote|unless)\s|^\s*def(?:exception|impl|macro|module|protocol)[(\s]`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Euphoria"),
-			regex.MustCompileMultiline(`^\s*namespace\s|^\s*(?:public\s+)?include\s|^\s*(?:(?:public|export|global)\s+)?(?:atom|constant|enum|function|integer|object|procedure|sequence|type)\s`),
+			rule.Multiline(`^\s*namespace\s|^\s*(?:public\s+)?include\s|^\s*(?:(?:public|export|global)\s+)?(?:atom|constant|enum|function|integer|object|procedure|sequence|type)\s`),
 		),
 	},
 	".f": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Forth"),
-			regex.MustCompileMultiline(`^: `),
+			rule.Multiline(`^: `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Filebench WML"),
-			regex.MustCompileMultiline(`flowop`),
+			rule.Multiline(`flowop`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Fortran"),
-			regex.MustCompileMultiline(`^(?i:[c*][^abd-z]|      (subroutine|program|end|data)\s|\s*!)`),
+			rule.Multiline(`^(?i:[c*][^abd-z]|      (subroutine|program|end|data)\s|\s*!)`),
 		),
 	},
 	".for": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Forth"),
-			regex.MustCompileMultiline(`^: `),
+			rule.Multiline(`^: `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Fortran"),
-			regex.MustCompileMultiline(`^(?i:[c*][^abd-z]|      (subroutine|program|end|data)\s|\s*!)`),
+			rule.Multiline(`^(?i:[c*][^abd-z]|      (subroutine|program|end|data)\s|\s*!)`),
 		),
 	},
 	".fr": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Forth"),
-			regex.MustCompileMultiline(`^(: |also |new-device|previous )`),
+			rule.Multiline(`^(: |also |new-device|previous )`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Frege"),
-			regex.MustCompileMultiline(`^\s*(import|module|package|data|type) `),
+			rule.Multiline(`^\s*(import|module|package|data|type) `),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Text"),
@@ -927,85 +924,85 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("VBA"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[ ]*VERSION [0-9]\.[0-9]{2}`),
+				rule.Multiline(`^[ ]*VERSION [0-9]\.[0-9]{2}`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^\s*Begin\s+\{[0-9A-Z\-]*\}\s?`),
+				rule.Multiline(`^\s*Begin\s+\{[0-9A-Z\-]*\}\s?`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Visual Basic 6.0"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[ ]*VERSION [0-9]\.[0-9]{2}`),
+				rule.Multiline(`^[ ]*VERSION [0-9]\.[0-9]{2}`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^\s*Begin\s+VB\.Form\s+`),
+				rule.Multiline(`^\s*Begin\s+VB\.Form\s+`),
 			),
 		),
 	},
 	".fs": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Forth"),
-			regex.MustCompileMultiline(`^(: |new-device)`),
+			rule.Multiline(`^(: |new-device)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("F#"),
-			regex.MustCompileMultiline(`^\s*(#light|import|let|module|namespace|open|type)`),
+			rule.Multiline(`^\s*(#light|import|let|module|namespace|open|type)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("GLSL"),
-			regex.MustCompileMultiline(`^\s*(#version|precision|uniform|varying|vec[234])`),
+			rule.Multiline(`^\s*(#version|precision|uniform|varying|vec[234])`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Filterscript"),
-			regex.MustCompileMultiline(`#include|#pragma\s+(rs|version)|__attribute__`),
+			rule.Multiline(`#include|#pragma\s+(rs|version)|__attribute__`),
 		),
 	},
 	".ftl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("FreeMarker"),
-			regex.MustCompileRuby(`^(?:<|[a-zA-Z-][a-zA-Z0-9_-]+[ \t]+\w)|\$\{\w+[^\r\n]*?\}|^[ \t]*(?:<#--.*?-->|<#([a-z]+)(?=\s|>)[^>]*>.*?</#\1>|\[#--.*?--\]|\[#([a-z]+)(?=\s|\])[^\]]*\].*?\[#\2\])`),
+			rule.Ruby(`^(?:<|[a-zA-Z-][a-zA-Z0-9_-]+[ \t]+\w)|\$\{\w+[^\r\n]*?\}|^[ \t]*(?:<#--.*?-->|<#([a-z]+)(?=\s|>)[^>]*>.*?</#\1>|\[#--.*?--\]|\[#([a-z]+)(?=\s|\])[^\]]*\].*?\[#\2\])`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Fluent"),
-			regex.MustCompileMultiline(`^-?[a-zA-Z][a-zA-Z0-9_-]* *=|\{\$-?[a-zA-Z][-\w]*(?:\.[a-zA-Z][-\w]*)?\}`),
+			rule.Multiline(`^-?[a-zA-Z][a-zA-Z0-9_-]* *=|\{\$-?[a-zA-Z][-\w]*(?:\.[a-zA-Z][-\w]*)?\}`),
 		),
 	},
 	".g": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("GAP"),
-			regex.MustCompileMultiline(`\s*(Declare|BindGlobal|KeyDependentOperation|Install(Method|GlobalFunction)|SetPackageInfo)`),
+			rule.Multiline(`\s*(Declare|BindGlobal|KeyDependentOperation|Install(Method|GlobalFunction)|SetPackageInfo)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("G-code"),
-			regex.MustCompileMultiline(`^[MG][0-9]+(?:\r?\n|\r)`),
+			rule.Multiline(`^[MG][0-9]+(?:\r?\n|\r)`),
 		),
 	},
 	".gd": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("GAP"),
-			regex.MustCompileMultiline(`\s*(Declare|BindGlobal|KeyDependentOperation)`),
+			rule.Multiline(`\s*(Declare|BindGlobal|KeyDependentOperation)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("GDScript"),
-			regex.MustCompileMultiline(`\s*(extends|var|const|enum|func|class|signal|tool|yield|assert|onready)`),
+			rule.Multiline(`\s*(extends|var|const|enum|func|class|signal|tool|yield|assert|onready)`),
 		),
 	},
 	".gml": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`(?i:^\s*(<\?xml|xmlns))`),
+			rule.Multiline(`(?i:^\s*(<\?xml|xmlns))`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Graph Modeling Language"),
-			regex.MustCompileMultiline(`(?i:^\s*(graph|node)\s+\[$)`),
+			rule.Multiline(`(?i:^\s*(graph|node)\s+\[$)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Gerber Image"),
-			regex.MustCompileMultiline(`^[DGMT][0-9]{2}\*$`),
+			rule.Multiline(`^[DGMT][0-9]{2}\*$`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Game Maker Language"),
@@ -1014,47 +1011,47 @@ var ContentHeuristics = map[string]*Heuristics{
 	".gs": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("GLSL"),
-			regex.MustCompileMultiline(`^#version\s+[0-9]+\b`),
+			rule.Multiline(`^#version\s+[0-9]+\b`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Gosu"),
-			regex.MustCompileMultiline(`^uses (java|gw)\.`),
+			rule.Multiline(`^uses (java|gw)\.`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Genie"),
-			regex.MustCompileMultiline(`^\[indent=[0-9]+\]`),
+			rule.Multiline(`^\[indent=[0-9]+\]`),
 		),
 	},
 	".gsc": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("GSC"),
-			regex.MustCompileRuby(`^\s*#\s*(?:using|insert|include|define|namespace)[ \t]+\w|^\s*(?>(?:autoexec|private)\s+){0,2}function\s+(?>(?:autoexec|private)\s+){0,2}\w+\s*\(|\b(?:level|self)[ \t]+thread[ \t]+(?:\[\[[ \t]*(?>\w+\.)*\w+[ \t]*\]\]|\w+)[ \t]*\([^\r\n\)]*\)[ \t]*;|^[ \t]*#[ \t]*(?:precache|using_animtree)[ \t]*\(`),
+			rule.Ruby(`^\s*#\s*(?:using|insert|include|define|namespace)[ \t]+\w|^\s*(?>(?:autoexec|private)\s+){0,2}function\s+(?>(?:autoexec|private)\s+){0,2}\w+\s*\(|\b(?:level|self)[ \t]+thread[ \t]+(?:\[\[[ \t]*(?>\w+\.)*\w+[ \t]*\]\]|\w+)[ \t]*\([^\r\n\)]*\)[ \t]*;|^[ \t]*#[ \t]*(?:precache|using_animtree)[ \t]*\(`),
 		),
 	},
 	".gsh": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("GSC"),
-			regex.MustCompileRuby(`^\s*#\s*(?:using|insert|include|define|namespace)[ \t]+\w|^\s*(?>(?:autoexec|private)\s+){0,2}function\s+(?>(?:autoexec|private)\s+){0,2}\w+\s*\(|\b(?:level|self)[ \t]+thread[ \t]+(?:\[\[[ \t]*(?>\w+\.)*\w+[ \t]*\]\]|\w+)[ \t]*\([^\r\n\)]*\)[ \t]*;|^[ \t]*#[ \t]*(?:precache|using_animtree)[ \t]*\(`),
+			rule.Ruby(`^\s*#\s*(?:using|insert|include|define|namespace)[ \t]+\w|^\s*(?>(?:autoexec|private)\s+){0,2}function\s+(?>(?:autoexec|private)\s+){0,2}\w+\s*\(|\b(?:level|self)[ \t]+thread[ \t]+(?:\[\[[ \t]*(?>\w+\.)*\w+[ \t]*\]\]|\w+)[ \t]*\([^\r\n\)]*\)[ \t]*;|^[ \t]*#[ \t]*(?:precache|using_animtree)[ \t]*\(`),
 		),
 	},
 	".gts": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Gerber Image"),
-			regex.MustCompileMultiline(`^G0.`),
+			rule.Multiline(`^G0.`),
 		),
 		rule.Not(
 			rule.MatchingLanguages("Glimmer TS"),
-			regex.MustCompileMultiline(`^G0.`),
+			rule.Multiline(`^G0.`),
 		),
 	},
 	".h": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Objective-C"),
-			regex.MustCompileMultiline(`^\s*(@(interface|class|protocol|property|end|synchronised|selector|implementation)\b|#import\s+.+\.h[">])`),
+			rule.Multiline(`^\s*(@(interface|class|protocol|property|end|synchronised|selector|implementation)\b|#import\s+.+\.h[">])`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("C++"),
-			regex.MustCompileMultiline(`^\s*#\s*include <(cstdint|string|vector|map|list|array|bitset|queue|stack|forward_list|unordered_map|unordered_set|(i|o|io)stream)>|^\s*template\s*<|^[ \t]*(try|constexpr)|^[ \t]*catch\s*\(|^[ \t]*(class|(using[ \t]+)?namespace)\s+\w+|^[ \t]*(private|public|protected):$|__has_cpp_attribute|__cplusplus >|std::\w+`),
+			rule.Multiline(`^\s*#\s*include <(cstdint|string|vector|map|list|array|bitset|queue|stack|forward_list|unordered_map|unordered_set|(i|o|io)stream)>|^\s*template\s*<|^[ \t]*(try|constexpr)|^[ \t]*catch\s*\(|^[ \t]*(class|(using[ \t]+)?namespace)\s+\w+|^[ \t]*(private|public|protected):$|__has_cpp_attribute|__cplusplus >|std::\w+`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("C"),
@@ -1063,13 +1060,13 @@ var ContentHeuristics = map[string]*Heuristics{
 	".hh": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Hack"),
-			regex.MustCompileMultiline(`<\?hh`),
+			rule.Multiline(`<\?hh`),
 		),
 	},
 	".html": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Ecmarkup"),
-			regex.MustCompileMultiline(`<emu-(?:alg|annex|biblio|clause|eqn|example|figure|gann|gmod|gprose|grammar|intro|not-ref|note|nt|prodref|production|rhs|table|t|xref)(?:$|\s|>)`),
+			rule.Multiline(`<emu-(?:alg|annex|biblio|clause|eqn|example|figure|gann|gmod|gprose|grammar|intro|not-ref|note|nt|prodref|production|rhs|table|t|xref)(?:$|\s|>)`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("HTML"),
@@ -1078,17 +1075,17 @@ var ContentHeuristics = map[string]*Heuristics{
 	".i": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Motorola 68K Assembly"),
-			regex.MustCompileMultiline(`(?im)\bmoveq(?:\.l)?\s+#(?:\$-?[0-9a-f]{1,3}|%[0-1]{1,8}|-?[0-9]{1,3}),\s*d[0-7]\b|(?im)^\s*move(?:\.[bwl])?\s+(?:sr|usp),\s*[^\s]+|(?im)^\s*move\.[bwl]\s+.*\b[ad]\d|(?im)^\s*movem\.[bwl]\b|(?im)^\s*move[mp](?:\.[wl])?\b|(?im)^\s*btst\b|(?im)^\s*dbra\b`),
+			rule.Multiline(`(?im)\bmoveq(?:\.l)?\s+#(?:\$-?[0-9a-f]{1,3}|%[0-1]{1,8}|-?[0-9]{1,3}),\s*d[0-7]\b|(?im)^\s*move(?:\.[bwl])?\s+(?:sr|usp),\s*[^\s]+|(?im)^\s*move\.[bwl]\s+.*\b[ad]\d|(?im)^\s*movem\.[bwl]\b|(?im)^\s*move[mp](?:\.[wl])?\b|(?im)^\s*btst\b|(?im)^\s*dbra\b`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("SWIG"),
-			regex.MustCompileMultiline(`^[ \t]*%[a-z_]+\b|^%[{}]$`),
+			rule.Multiline(`^[ \t]*%[a-z_]+\b|^%[{}]$`),
 		),
 	},
 	".ice": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("JSON"),
-			regex.MustCompileMultiline(`\A\s*[{\[]`),
+			rule.Multiline(`\A\s*[{\[]`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Slice"),
@@ -1097,41 +1094,41 @@ var ContentHeuristics = map[string]*Heuristics{
 	".inc": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Motorola 68K Assembly"),
-			regex.MustCompileMultiline(`(?im)\bmoveq(?:\.l)?\s+#(?:\$-?[0-9a-f]{1,3}|%[0-1]{1,8}|-?[0-9]{1,3}),\s*d[0-7]\b|(?im)^\s*move(?:\.[bwl])?\s+(?:sr|usp),\s*[^\s]+|(?im)^\s*move\.[bwl]\s+.*\b[ad]\d|(?im)^\s*movem\.[bwl]\b|(?im)^\s*move[mp](?:\.[wl])?\b|(?im)^\s*btst\b|(?im)^\s*dbra\b`),
+			rule.Multiline(`(?im)\bmoveq(?:\.l)?\s+#(?:\$-?[0-9a-f]{1,3}|%[0-1]{1,8}|-?[0-9]{1,3}),\s*d[0-7]\b|(?im)^\s*move(?:\.[bwl])?\s+(?:sr|usp),\s*[^\s]+|(?im)^\s*move\.[bwl]\s+.*\b[ad]\d|(?im)^\s*movem\.[bwl]\b|(?im)^\s*move[mp](?:\.[wl])?\b|(?im)^\s*btst\b|(?im)^\s*dbra\b`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("PHP"),
-			regex.MustCompileMultiline(`^<\?(?:php)?`),
+			rule.Multiline(`^<\?(?:php)?`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("SourcePawn"),
-			regex.MustCompileMultiline(`^public\s+(?:SharedPlugin(?:\s+|:)__pl_\w+\s*=(?:\s*\{)?|(?:void\s+)?__pl_\w+_SetNTVOptional\(\)(?:\s*\{)?)|^methodmap\s+\w+\s+<\s+\w+|^\s*MarkNativeAsOptional\s*\(`),
+			rule.Multiline(`^public\s+(?:SharedPlugin(?:\s+|:)__pl_\w+\s*=(?:\s*\{)?|(?:void\s+)?__pl_\w+_SetNTVOptional\(\)(?:\s*\{)?)|^methodmap\s+\w+\s+<\s+\w+|^\s*MarkNativeAsOptional\s*\(`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("NASL"),
-			regex.MustCompileRuby(`^\s*include\s*\(\s*(?:"|')[\\/\w\-\.:\s]+\.(?:nasl|inc)\s*(?:"|')\s*\)\s*;|^\s*(?:global|local)_var\s+(?:\w+(?:\s*=\s*[\w\-"']+)?\s*)(?:,\s*\w+(?:\s*=\s*[\w\-"']+)?\s*)*+\s*;|^\s*namespace\s+\w+\s*\{|^\s*object\s+\w+\s*(?:extends\s+\w+(?:::\w+)?)?\s*\{|^\s*(?:public\s+|private\s+|\s*)function\s+\w+\s*\([\w\s,]*\)\s*\{`),
+			rule.Ruby(`^\s*include\s*\(\s*(?:"|')[\\/\w\-\.:\s]+\.(?:nasl|inc)\s*(?:"|')\s*\)\s*;|^\s*(?:global|local)_var\s+(?:\w+(?:\s*=\s*[\w\-"']+)?\s*)(?:,\s*\w+(?:\s*=\s*[\w\-"']+)?\s*)*+\s*;|^\s*namespace\s+\w+\s*\{|^\s*object\s+\w+\s*(?:extends\s+\w+(?:::\w+)?)?\s*\{|^\s*(?:public\s+|private\s+|\s*)function\s+\w+\s*\([\w\s,]*\)\s*\{`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("POV-Ray SDL"),
-			regex.MustCompileMultiline(`^\s*#(declare|local|macro|while)\s`),
+			rule.Multiline(`^\s*#(declare|local|macro|while)\s`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Pascal"),
-			regex.MustCompileMultiline(`(?i:^\s*\{\$(?:mode|ifdef|undef|define)[ ]+[a-z0-9_]+\})|^\s*end[.;]\s*$`),
+			rule.Multiline(`(?i:^\s*\{\$(?:mode|ifdef|undef|define)[ ]+[a-z0-9_]+\})|^\s*end[.;]\s*$`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("BitBake"),
-			regex.MustCompileMultiline(`^inherit(\s+[\w.-]+)+\s*$`),
+			rule.Multiline(`^inherit(\s+[\w.-]+)+\s*$`),
 		),
 	},
 	".json": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("OASv2-json"),
-			regex.MustCompileMultiline(`"swagger":\s?"2.[0-9.]+"`),
+			rule.Multiline(`"swagger":\s?"2.[0-9.]+"`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("OASv3-json"),
-			regex.MustCompileMultiline(`"openapi":\s?"3.[0-9.]+"`),
+			rule.Multiline(`"openapi":\s?"3.[0-9.]+"`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("JSON"),
@@ -1140,45 +1137,45 @@ var ContentHeuristics = map[string]*Heuristics{
 	".l": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Common Lisp"),
-			regex.MustCompileMultiline(`\(def(un|macro)\s`),
+			rule.Multiline(`\(def(un|macro)\s`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Lex"),
-			regex.MustCompileMultiline(`^(%[%{}]xs|<.*>)`),
+			rule.Multiline(`^(%[%{}]xs|<.*>)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.[A-Za-z]{2}(\s|$)`),
+			rule.Multiline(`^\.[A-Za-z]{2}(\s|$)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("PicoLisp"),
-			regex.MustCompileMultiline(`^\((de|class|rel|code|data|must)\s`),
+			rule.Multiline(`^\((de|class|rel|code|data|must)\s`),
 		),
 	},
 	".lean": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Lean"),
-			regex.MustCompileMultiline(`^import [a-z]`),
+			rule.Multiline(`^import [a-z]`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Lean 4"),
-			regex.MustCompileMultiline(`^import [A-Z]`),
+			rule.Multiline(`^import [A-Z]`),
 		),
 	},
 	".lisp": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Common Lisp"),
-			regex.MustCompileMultiline(`^\s*\((?i:defun|in-package|defpackage) `),
+			rule.Multiline(`^\s*\((?i:defun|in-package|defpackage) `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("NewLisp"),
-			regex.MustCompileMultiline(`^\s*\(define `),
+			rule.Multiline(`^\s*\(define `),
 		),
 	},
 	".ls": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("LoomScript"),
-			regex.MustCompileMultiline(`^\s*package\s*[\w\.\/\*\s]*\s*\{`),
+			rule.Multiline(`^\s*package\s*[\w\.\/\*\s]*\s*\{`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("LiveScript"),
@@ -1187,54 +1184,54 @@ var ContentHeuristics = map[string]*Heuristics{
 	".lsp": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Common Lisp"),
-			regex.MustCompileMultiline(`^\s*\((?i:defun|in-package|defpackage) `),
+			rule.Multiline(`^\s*\((?i:defun|in-package|defpackage) `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("NewLisp"),
-			regex.MustCompileMultiline(`^\s*\(define `),
+			rule.Multiline(`^\s*\(define `),
 		),
 	},
 	".m": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Objective-C"),
-			regex.MustCompileMultiline(`^\s*(@(interface|class|protocol|property|end|synchronised|selector|implementation)\b|#import\s+.+\.h[">])`),
+			rule.Multiline(`^\s*(@(interface|class|protocol|property|end|synchronised|selector|implementation)\b|#import\s+.+\.h[">])`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Mercury"),
-			regex.MustCompileMultiline(`:- module`),
+			rule.Multiline(`:- module`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("MUF"),
-			regex.MustCompileMultiline(`^: `),
+			rule.Multiline(`^: `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("M"),
-			regex.MustCompileMultiline(`^\s*;`),
+			rule.Multiline(`^\s*;`),
 		),
 		rule.And(
 			rule.MatchingLanguages("Mathematica"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`\(\*`),
+				rule.Multiline(`\(\*`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`\*\)$`),
+				rule.Multiline(`\*\)$`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("MATLAB"),
-			regex.MustCompileMultiline(`^\s*%`),
+			rule.Multiline(`^\s*%`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Limbo"),
-			regex.MustCompileMultiline(`^\w+\s*:\s*module\s*\{`),
+			rule.Multiline(`^\w+\s*:\s*module\s*\{`),
 		),
 	},
 	".m4": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("M4Sugar"),
-			regex.MustCompileMultiline(`AC_DEFUN|AC_PREREQ|AC_INIT|^_?m4_`),
+			rule.Multiline(`AC_DEFUN|AC_PREREQ|AC_INIT|^_?m4_`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("M4"),
@@ -1245,26 +1242,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -1274,31 +1271,31 @@ var ContentHeuristics = map[string]*Heuristics{
 	".mask": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Unity3D Asset"),
-			regex.MustCompileMultiline(`tag:unity3d.com`),
+			rule.Multiline(`tag:unity3d.com`),
 		),
 	},
 	".mc": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Win32 Message File"),
-			regex.MustCompileRuby(`(?i)^[ \t]*(?>\/\*\s*)?MessageId=|^\.$`),
+			rule.Ruby(`(?i)^[ \t]*(?>\/\*\s*)?MessageId=|^\.$`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("M4"),
-			regex.MustCompileMultiline(`^dnl|^divert\((?:-?\d+)?\)|^\w+\(`+"`"+`[^\r\n]*?'[),]`),
+			rule.Multiline(`^dnl|^divert\((?:-?\d+)?\)|^\w+\(`+"`"+`[^\r\n]*?'[),]`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Monkey C"),
-			regex.MustCompileMultiline(`\b(?:using|module|function|class|var)\s+\w`),
+			rule.Multiline(`\b(?:using|module|function|class|var)\s+\w`),
 		),
 	},
 	".md": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Markdown"),
-			regex.MustCompileMultiline(`(^[-A-Za-z0-9=#!\*\[|>])|<\/|\A\z`),
+			rule.Multiline(`(^[-A-Za-z0-9=#!\*\[|>])|<\/|\A\z`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("GCC Machine Description"),
-			regex.MustCompileMultiline(`^(;;|\(define_)`),
+			rule.Multiline(`^(;;|\(define_)`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Markdown"),
@@ -1309,26 +1306,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Dd +(?:[^"\s]+|"[^"]+")`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*Dt +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
+				rule.Multiline(`^[.'][ \t]*Sh +(?:[^"\s]|"[^"]+")`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Roff Manpage"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
+				rule.Multiline(`^[.'][ \t]*TH +(?:[^"\s]+|"[^"]+") +"?(?:[1-9]|@[^\s@]+@)`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
+				rule.Multiline(`^[.'][ \t]*SH +(?:[^"\s]+|"[^"\s]+)`),
 			),
 		),
 		rule.Always(
@@ -1338,25 +1335,25 @@ var ContentHeuristics = map[string]*Heuristics{
 	".ml": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("OCaml"),
-			regex.MustCompileMultiline(`(^\s*module)|let rec |match\s+(\S+\s)+with`),
+			rule.Multiline(`(^\s*module)|let rec |match\s+(\S+\s)+with`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Standard ML"),
-			regex.MustCompileMultiline(`=> |case\s+(\S+\s)+of`),
+			rule.Multiline(`=> |case\s+(\S+\s)+of`),
 		),
 	},
 	".mod": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`<!ENTITY `),
+			rule.Multiline(`<!ENTITY `),
 		),
 		rule.Or(
 			rule.MatchingLanguages("NMODL"),
-			regex.MustCompileMultiline(`\b(NEURON|INITIAL|UNITS)\b`),
+			rule.Multiline(`\b(NEURON|INITIAL|UNITS)\b`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Modula-2"),
-			regex.MustCompileMultiline(`^\s*(?i:MODULE|END) [\w\.]+;`),
+			rule.Multiline(`^\s*(?i:MODULE|END) [\w\.]+;`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Linux Kernel Module", "AMPL"),
@@ -1365,27 +1362,27 @@ var ContentHeuristics = map[string]*Heuristics{
 	".mojo": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Mojo"),
-			regex.MustCompileMultiline(`^\s*(alias|def|from|fn|import|struct|trait)\s`),
+			rule.Multiline(`^\s*(alias|def|from|fn|import|struct|trait)\s`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`^\s*<\?xml`),
+			rule.Multiline(`^\s*<\?xml`),
 		),
 	},
 	".ms": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^[.'][A-Za-z]{2}(\s|$)`),
+			rule.Multiline(`^[.'][A-Za-z]{2}(\s|$)`),
 		),
 		rule.And(
 			rule.MatchingLanguages("Unix Assembly"),
 			rule.Not(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`/\*`),
+				rule.Multiline(`/\*`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^\s*\.(?:include\s|globa?l\s|[A-Za-z][_A-Za-z0-9]*:)`),
+				rule.Multiline(`^\s*\.(?:include\s|globa?l\s|[A-Za-z][_A-Za-z0-9]*:)`),
 			),
 		),
 		rule.Always(
@@ -1395,37 +1392,37 @@ var ContentHeuristics = map[string]*Heuristics{
 	".msg": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("omnetpp-msg"),
-			regex.MustCompileMultiline(`^cplusplus \{\{|^namespace[\s]*([^.\s]*\.)*[^.\s]*;|^struct \{|^message [\S]* (extends)? [\S]*[\s]*\{|^packet \{|^class (extends) [\S]*[\s]*\{|^enum \{|^import ([^.\s]*\.)*[^.\s]*;`),
+			rule.Multiline(`^cplusplus \{\{|^namespace[\s]*([^.\s]*\.)*[^.\s]*;|^struct \{|^message [\S]* (extends)? [\S]*[\s]*\{|^packet \{|^class (extends) [\S]*[\s]*\{|^enum \{|^import ([^.\s]*\.)*[^.\s]*;`),
 		),
 	},
 	".n": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^[.']`),
+			rule.Multiline(`^[.']`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Nemerle"),
-			regex.MustCompileMultiline(`^(module|namespace|using)\s`),
+			rule.Multiline(`^(module|namespace|using)\s`),
 		),
 	},
 	".ncl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`^\s*<\?xml\s+version`),
+			rule.Multiline(`^\s*<\?xml\s+version`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Gerber Image"),
-			regex.MustCompileMultiline(`^[DGMT][0-9]{2}\*(?:\r?\n|\r)`),
+			rule.Multiline(`^[DGMT][0-9]{2}\*(?:\r?\n|\r)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Text"),
-			regex.MustCompileMultiline(`THE_TITLE`),
+			rule.Multiline(`THE_TITLE`),
 		),
 	},
 	".nl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("NL"),
-			regex.MustCompileMultiline(`^(b|g)[0-9]+ `),
+			rule.Multiline(`^(b|g)[0-9]+ `),
 		),
 		rule.Always(
 			rule.MatchingLanguages("NewLisp"),
@@ -1434,7 +1431,7 @@ var ContentHeuristics = map[string]*Heuristics{
 	".nr": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.`),
+			rule.Multiline(`^\.`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Noir"),
@@ -1443,7 +1440,7 @@ var ContentHeuristics = map[string]*Heuristics{
 	".nu": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Nushell"),
-			regex.MustCompileMultiline(`^\s*(import|export|module|def|let|let-env) `),
+			rule.Multiline(`^\s*(import|export|module|def|let|let-env) `),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Nu"),
@@ -1452,17 +1449,17 @@ var ContentHeuristics = map[string]*Heuristics{
 	".odin": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Object Data Instance Notation"),
-			regex.MustCompileMultiline(`(?:^|<)\s*[A-Za-z0-9_]+\s*=\s*<`),
+			rule.Multiline(`(?:^|<)\s*[A-Za-z0-9_]+\s*=\s*<`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Odin"),
-			regex.MustCompileMultiline(`package\s+\w+|\b(?:im|ex)port\s*"[\w:./]+"|\w+\s*::\s*(?:proc|struct)\s*\(|^\s*//\s`),
+			rule.Multiline(`package\s+\w+|\b(?:im|ex)port\s*"[\w:./]+"|\w+\s*::\s*(?:proc|struct)\s*\(|^\s*//\s`),
 		),
 	},
 	".p": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Gnuplot"),
-			regex.MustCompileMultiline(`^s?plot\b|^set\s+(term|terminal|out|output|[xy]tics|[xy]label|[xy]range|style)\b`),
+			rule.Multiline(`^s?plot\b|^set\s+(term|terminal|out|output|[xy]tics|[xy]label|[xy]range|style)\b`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("OpenEdge ABL"),
@@ -1471,17 +1468,17 @@ var ContentHeuristics = map[string]*Heuristics{
 	".php": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Hack"),
-			regex.MustCompileMultiline(`<\?hh`),
+			rule.Multiline(`<\?hh`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("PHP"),
-			regex.MustCompileMultiline(`<\?[^h]`),
+			rule.Multiline(`<\?[^h]`),
 		),
 	},
 	".pkl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Pkl"),
-			regex.MustCompileMultiline(`^\s*(module|import|amends|extends|local|const|fixed|abstract|open|class|typealias|@\w+)\b|^\s*[a-zA-Z0-9_$]+\s*(=|{|:)|^\s*`+"`"+`[^`+"`"+`]+`+"`"+`\s*(=|{|:)|for\s*\(|when\s*\(`),
+			rule.Multiline(`^\s*(module|import|amends|extends|local|const|fixed|abstract|open|class|typealias|@\w+)\b|^\s*[a-zA-Z0-9_$]+\s*(=|{|:)|^\s*`+"`"+`[^`+"`"+`]+`+"`"+`\s*(=|{|:)|for\s*\(|when\s*\(`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Pickle"),
@@ -1490,28 +1487,28 @@ var ContentHeuristics = map[string]*Heuristics{
 	".pl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Prolog"),
-			regex.MustCompileMultiline(`^[^#]*:-`),
+			rule.Multiline(`^[^#]*:-`),
 		),
 		rule.And(
 			rule.MatchingLanguages("Perl"),
 			rule.Not(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^\s*use\s+v6\b`),
+				rule.Multiline(`^\s*use\s+v6\b`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`\buse\s+(?:strict\b|v?5\b)|^\s*use\s+(?:constant|overload)\b|^\s*(?:\*|(?:our\s*)?@)EXPORT\s*=|^\s*package\s+[^\W\d]\w*(?:::\w+)*\s*(?:[;{]|\sv?\d)|[\s$][^\W\d]\w*(?::\w+)*->[a-zA-Z_\[({]`),
+				rule.Multiline(`\buse\s+(?:strict\b|v?5\b)|^\s*use\s+(?:constant|overload)\b|^\s*(?:\*|(?:our\s*)?@)EXPORT\s*=|^\s*package\s+[^\W\d]\w*(?:::\w+)*\s*(?:[;{]|\sv?\d)|[\s$][^\W\d]\w*(?::\w+)*->[a-zA-Z_\[({]`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Raku"),
-			regex.MustCompileMultiline(`^\s*(?:use\s+v6\b|\bmodule\b|\b(?:my\s+)?class\b)`),
+			rule.Multiline(`^\s*(?:use\s+v6\b|\bmodule\b|\b(?:my\s+)?class\b)`),
 		),
 	},
 	".plist": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("XML Property List"),
-			regex.MustCompileMultiline(`^\s*(?:<\?xml\s|<!DOCTYPE\s+plist|<plist(?:\s+version\s*=\s*["']\d+(?:\.\d+)?["'])?\s*>\s*$)`),
+			rule.Multiline(`^\s*(?:<\?xml\s|<!DOCTYPE\s+plist|<plist(?:\s+version\s*=\s*["']\d+(?:\.\d+)?["'])?\s*>\s*$)`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("OpenStep Property List"),
@@ -1520,7 +1517,7 @@ var ContentHeuristics = map[string]*Heuristics{
 	".plt": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Prolog"),
-			regex.MustCompileMultiline(`^\s*:-`),
+			rule.Multiline(`^\s*:-`),
 		),
 	},
 	".pm": &Heuristics{
@@ -1528,26 +1525,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Perl"),
 			rule.Not(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^\s*use\s+v6\b`),
+				rule.Multiline(`^\s*use\s+v6\b`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`\buse\s+(?:strict\b|v?5\b)|^\s*use\s+(?:constant|overload)\b|^\s*(?:\*|(?:our\s*)?@)EXPORT\s*=|^\s*package\s+[^\W\d]\w*(?:::\w+)*\s*(?:[;{]|\sv?\d)|[\s$][^\W\d]\w*(?::\w+)*->[a-zA-Z_\[({]`),
+				rule.Multiline(`\buse\s+(?:strict\b|v?5\b)|^\s*use\s+(?:constant|overload)\b|^\s*(?:\*|(?:our\s*)?@)EXPORT\s*=|^\s*package\s+[^\W\d]\w*(?:::\w+)*\s*(?:[;{]|\sv?\d)|[\s$][^\W\d]\w*(?::\w+)*->[a-zA-Z_\[({]`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Raku"),
-			regex.MustCompileMultiline(`^\s*(?:use\s+v6\b|\bmodule\b|\b(?:my\s+)?class\b)`),
+			rule.Multiline(`^\s*(?:use\s+v6\b|\bmodule\b|\b(?:my\s+)?class\b)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("X PixMap"),
-			regex.MustCompileMultiline(`^\s*\/\* XPM \*\/`),
+			rule.Multiline(`^\s*\/\* XPM \*\/`),
 		),
 	},
 	".pod": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Pod 6"),
-			regex.MustCompileMultiline(`^[\s&&[^\r\n]]*=(comment|begin pod|begin para|item\d+)`),
+			rule.Multiline(`^[\s&&[^\r\n]]*=(comment|begin pod|begin para|item\d+)`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Pod"),
@@ -1556,40 +1553,40 @@ var ContentHeuristics = map[string]*Heuristics{
 	".pp": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Pascal"),
-			regex.MustCompileMultiline(`^\s*end[.;]`),
+			rule.Multiline(`^\s*end[.;]`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Puppet"),
-			regex.MustCompileMultiline(`^\s+\w+\s+=>\s`),
+			rule.Multiline(`^\s+\w+\s+=>\s`),
 		),
 	},
 	".pro": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Proguard"),
-			regex.MustCompileMultiline(`^-(include\b.*\.pro$|keep\b|keepclassmembers\b|keepattributes\b)`),
+			rule.Multiline(`^-(include\b.*\.pro$|keep\b|keepclassmembers\b|keepattributes\b)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Prolog"),
-			regex.MustCompileMultiline(`^[^\[#]+:-`),
+			rule.Multiline(`^[^\[#]+:-`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("INI"),
-			regex.MustCompileMultiline(`last_client=`),
+			rule.Multiline(`last_client=`),
 		),
 		rule.And(
 			rule.MatchingLanguages("QMake"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`HEADERS`),
+				rule.Multiline(`HEADERS`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`SOURCES`),
+				rule.Multiline(`SOURCES`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("IDL"),
-			regex.MustCompileMultiline(`^\s*(?i:function|pro|compile_opt) \w[ \w,:]*$`),
+			rule.Multiline(`^\s*(?i:function|pro|compile_opt) \w[ \w,:]*$`),
 		),
 	},
 	".properties": &Heuristics{
@@ -1597,103 +1594,103 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("INI"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[^#!;][^=]*=`),
+				rule.Multiline(`^[^#!;][^=]*=`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[;\[]`),
+				rule.Multiline(`^[;\[]`),
 			),
 		),
 		rule.And(
 			rule.MatchingLanguages("Java Properties"),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[^#!;][^=]*=`),
+				rule.Multiline(`^[^#!;][^=]*=`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^[#!]`),
+				rule.Multiline(`^[#!]`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("INI"),
-			regex.MustCompileMultiline(`^[^#!;][^=]*=`),
+			rule.Multiline(`^[^#!;][^=]*=`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Java Properties"),
-			regex.MustCompileMultiline(`^[^#!][^:]*:`),
+			rule.Multiline(`^[^#!][^:]*:`),
 		),
 	},
 	".q": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("q"),
-			regex.MustCompileMultiline(`((?i:[A-Z.][\w.]*:\{)|^\\(cd?|d|l|p|ts?) )`),
+			rule.Multiline(`((?i:[A-Z.][\w.]*:\{)|^\\(cd?|d|l|p|ts?) )`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("HiveQL"),
-			regex.MustCompileMultiline(`(?i:SELECT\s+[\w*,]+\s+FROM|(CREATE|ALTER|DROP)\s(DATABASE|SCHEMA|TABLE))`),
+			rule.Multiline(`(?i:SELECT\s+[\w*,]+\s+FROM|(CREATE|ALTER|DROP)\s(DATABASE|SCHEMA|TABLE))`),
 		),
 	},
 	".qs": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Q#"),
-			regex.MustCompileMultiline(`^((\/{2,3})?\s*(namespace|operation)\b)`),
+			rule.Multiline(`^((\/{2,3})?\s*(namespace|operation)\b)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Qt Script"),
-			regex.MustCompileMultiline(`(\w+\.prototype\.\w+|===|\bvar\b)`),
+			rule.Multiline(`(\w+\.prototype\.\w+|===|\bvar\b)`),
 		),
 	},
 	".r": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Rebol"),
-			regex.MustCompileMultiline(`(?i:\bRebol\b)`),
+			rule.Multiline(`(?i:\bRebol\b)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Rez"),
-			regex.MustCompileMultiline(`(#include\s+["<](Types\.r|Carbon\/Carbon\.r)[">])|((resource|data|type)\s+'[A-Za-z0-9]{4}'\s+((\(.*\)\s+){0,1}){)`),
+			rule.Multiline(`(#include\s+["<](Types\.r|Carbon\/Carbon\.r)[">])|((resource|data|type)\s+'[A-Za-z0-9]{4}'\s+((\(.*\)\s+){0,1}){)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("R"),
-			regex.MustCompileMultiline(`<-|^\s*#`),
+			rule.Multiline(`<-|^\s*#`),
 		),
 	},
 	".re": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Reason"),
-			regex.MustCompileMultiline(`^\s*module\s+type\s|^\s*(?:include|open)\s+\w+\s*;\s*$|^\s*let\s+(?:module\s\w+\s*=\s*\{|\w+:\s+.*=.*;\s*$)`),
+			rule.Multiline(`^\s*module\s+type\s|^\s*(?:include|open)\s+\w+\s*;\s*$|^\s*let\s+(?:module\s\w+\s*=\s*\{|\w+:\s+.*=.*;\s*$)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("C++"),
-			regex.MustCompileMultiline(`^\s*#(?:(?:if|ifdef|define|pragma)\s+\w|\s*include\s+<[^>]+>)|^\s*template\s*<`),
+			rule.Multiline(`^\s*#(?:(?:if|ifdef|define|pragma)\s+\w|\s*include\s+<[^>]+>)|^\s*template\s*<`),
 		),
 	},
 	".res": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("ReScript"),
-			regex.MustCompileMultiline(`^\s*(let|module|type)\s+\w*\s+=\s+|^\s*(?:include|open)\s+\w+\s*$`),
+			rule.Multiline(`^\s*(let|module|type)\s+\w*\s+=\s+|^\s*(?:include|open)\s+\w+\s*$`),
 		),
 	},
 	".resource": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("RobotFramework"),
-			regex.MustCompileMultiline(`^\*{3} (Settings|Variables|Keywords) \*{3}$`),
+			rule.Multiline(`^\*{3} (Settings|Variables|Keywords) \*{3}$`),
 		),
 	},
 	".rno": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("RUNOFF"),
-			regex.MustCompileRuby(`(?i:^\.!|^\f|\f$|^\.end lit(?:eral)?\b|^\.[a-zA-Z].*?;\.[a-zA-Z](?:[; \t])|\^\*[^\s*][^*]*\\\*(?=$|\s)|^\.c;[ \t]*\w+)`),
+			rule.Ruby(`(?i:^\.!|^\f|\f$|^\.end lit(?:eral)?\b|^\.[a-zA-Z].*?;\.[a-zA-Z](?:[; \t])|\^\*[^\s*][^*]*\\\*(?=$|\s)|^\.c;[ \t]*\w+)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Roff"),
-			regex.MustCompileMultiline(`^\.\\" `),
+			rule.Multiline(`^\.\\" `),
 		),
 	},
 	".rpy": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Python"),
-			regex.MustCompileMultiline(`^(import|from|class|def)\s`),
+			rule.Multiline(`^(import|from|class|def)\s`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Ren'Py"),
@@ -1702,69 +1699,69 @@ var ContentHeuristics = map[string]*Heuristics{
 	".rs": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Rust"),
-			regex.MustCompileMultiline(`^(use |fn |mod |pub |macro_rules|impl|#!?\[)`),
+			rule.Multiline(`^(use |fn |mod |pub |macro_rules|impl|#!?\[)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("RenderScript"),
-			regex.MustCompileMultiline(`#include|#pragma\s+(rs|version)|__attribute__`),
+			rule.Multiline(`#include|#pragma\s+(rs|version)|__attribute__`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`^\s*<\?xml`),
+			rule.Multiline(`^\s*<\?xml`),
 		),
 	},
 	".s": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Motorola 68K Assembly"),
-			regex.MustCompileMultiline(`(?im)\bmoveq(?:\.l)?\s+#(?:\$-?[0-9a-f]{1,3}|%[0-1]{1,8}|-?[0-9]{1,3}),\s*d[0-7]\b|(?im)^\s*move(?:\.[bwl])?\s+(?:sr|usp),\s*[^\s]+|(?im)^\s*move\.[bwl]\s+.*\b[ad]\d|(?im)^\s*movem\.[bwl]\b|(?im)^\s*move[mp](?:\.[wl])?\b|(?im)^\s*btst\b|(?im)^\s*dbra\b`),
+			rule.Multiline(`(?im)\bmoveq(?:\.l)?\s+#(?:\$-?[0-9a-f]{1,3}|%[0-1]{1,8}|-?[0-9]{1,3}),\s*d[0-7]\b|(?im)^\s*move(?:\.[bwl])?\s+(?:sr|usp),\s*[^\s]+|(?im)^\s*move\.[bwl]\s+.*\b[ad]\d|(?im)^\s*movem\.[bwl]\b|(?im)^\s*move[mp](?:\.[wl])?\b|(?im)^\s*btst\b|(?im)^\s*dbra\b`),
 		),
 	},
 	".sc": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("SuperCollider"),
-			regex.MustCompileMultiline(`(?i:\^(this|super)\.|^\s*~\w+\s*=\.)`),
+			rule.Multiline(`(?i:\^(this|super)\.|^\s*~\w+\s*=\.)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Scala"),
-			regex.MustCompileMultiline(`(^\s*import (scala|java)\.|^\s*class\b)`),
+			rule.Multiline(`(^\s*import (scala|java)\.|^\s*class\b)`),
 		),
 	},
 	".scd": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("SuperCollider"),
-			regex.MustCompileMultiline(`(?i:\^(this|super)\.|^\s*(~\w+\s*=\.|SynthDef\b))`),
+			rule.Multiline(`(?i:\^(this|super)\.|^\s*(~\w+\s*=\.|SynthDef\b))`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Markdown"),
-			regex.MustCompileMultiline(`^#+\s+(NAME|SYNOPSIS|DESCRIPTION)`),
+			rule.Multiline(`^#+\s+(NAME|SYNOPSIS|DESCRIPTION)`),
 		),
 	},
 	".sol": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Solidity"),
-			regex.MustCompileRuby(`\bpragma\s+solidity\b|\b(?:abstract\s+)?contract\s+(?!\d)[a-zA-Z0-9$_]+(?:\s+is\s+(?:[a-zA-Z0-9$_][^\{]*?)?)?\s*\{`),
+			rule.Ruby(`\bpragma\s+solidity\b|\b(?:abstract\s+)?contract\s+(?!\d)[a-zA-Z0-9$_]+(?:\s+is\s+(?:[a-zA-Z0-9$_][^\{]*?)?)?\s*\{`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Gerber Image"),
-			regex.MustCompileMultiline(`^[DGMT][0-9]{2}\*(?:\r?\n|\r)`),
+			rule.Multiline(`^[DGMT][0-9]{2}\*(?:\r?\n|\r)`),
 		),
 	},
 	".sql": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("PLpgSQL"),
-			regex.MustCompileMultiline(`(?i:^\\i\b|AS\s+\$\$|LANGUAGE\s+'?plpgsql'?|BEGIN(\s+WORK)?\s*;)`),
+			rule.Multiline(`(?i:^\\i\b|AS\s+\$\$|LANGUAGE\s+'?plpgsql'?|BEGIN(\s+WORK)?\s*;)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("SQLPL"),
-			regex.MustCompileMultiline(`(?i:ALTER\s+MODULE|MODE\s+DB2SQL|\bSYS(CAT|PROC)\.|ASSOCIATE\s+RESULT\s+SET|\bEND!\s*$)`),
+			rule.Multiline(`(?i:ALTER\s+MODULE|MODE\s+DB2SQL|\bSYS(CAT|PROC)\.|ASSOCIATE\s+RESULT\s+SET|\bEND!\s*$)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("PLSQL"),
-			regex.MustCompileMultiline(`(?i:\$\$PLSQL_|XMLTYPE|systimestamp|\.nextval|CONNECT\s+BY|AUTHID\s+(DEFINER|CURRENT_USER)|constructor\W+function)`),
+			rule.Multiline(`(?i:\$\$PLSQL_|XMLTYPE|systimestamp|\.nextval|CONNECT\s+BY|AUTHID\s+(DEFINER|CURRENT_USER)|constructor\W+function)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("TSQL"),
-			regex.MustCompileMultiline(`(?i:^\s*GO\b|BEGIN(\s+TRY|\s+CATCH)|OUTPUT\s+INSERTED|DECLARE\s+@|\[dbo\])`),
+			rule.Multiline(`(?i:^\s*GO\b|BEGIN(\s+TRY|\s+CATCH)|OUTPUT\s+INSERTED|DECLARE\s+@|\[dbo\])`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("SQL"),
@@ -1773,23 +1770,23 @@ var ContentHeuristics = map[string]*Heuristics{
 	".srt": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("SubRip Text"),
-			regex.MustCompileMultiline(`^(\d{2}:\d{2}:\d{2},\d{3})\s*(-->)\s*(\d{2}:\d{2}:\d{2},\d{3})$`),
+			rule.Multiline(`^(\d{2}:\d{2}:\d{2},\d{3})\s*(-->)\s*(\d{2}:\d{2}:\d{2},\d{3})$`),
 		),
 	},
 	".st": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("StringTemplate"),
-			regex.MustCompileRuby(`\$\w+[($]|(.)!\s*.+?\s*!\1|<!\s*.+?\s*!>|\[!\s*.+?\s*!\]|\{!\s*.+?\s*!\}`),
+			rule.Ruby(`\$\w+[($]|(.)!\s*.+?\s*!\1|<!\s*.+?\s*!>|\[!\s*.+?\s*!\]|\{!\s*.+?\s*!\}`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Smalltalk"),
-			regex.MustCompileMultiline(`\A\s*[\[{(^"'\w#]|[a-zA-Z_]\w*\s*:=\s*[a-zA-Z_]\w*|class\s*>>\s*[a-zA-Z_]\w*|^[a-zA-Z_]\w*\s+[a-zA-Z_]\w*:|^Class\s*\{|if(?:True|False):\s*\[`),
+			rule.Multiline(`\A\s*[\[{(^"'\w#]|[a-zA-Z_]\w*\s*:=\s*[a-zA-Z_]\w*|class\s*>>\s*[a-zA-Z_]\w*|^[a-zA-Z_]\w*\s+[a-zA-Z_]\w*:|^Class\s*\{|if(?:True|False):\s*\[`),
 		),
 	},
 	".star": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("STAR"),
-			regex.MustCompileMultiline(`^loop_\s*$`),
+			rule.Multiline(`^loop_\s*$`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Starlark"),
@@ -1798,17 +1795,17 @@ var ContentHeuristics = map[string]*Heuristics{
 	".stl": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("STL"),
-			regex.MustCompileMultiline(`\A\s*solid(?:$|\s)[\s\S]*^endsolid(?:$|\s)`),
+			rule.Multiline(`\A\s*solid(?:$|\s)[\s\S]*^endsolid(?:$|\s)`),
 		),
 	},
 	".sw": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Sway"),
-			regex.MustCompileMultiline(`^\s*(?:(?:abi|dep|fn|impl|mod|pub|trait)\s|#\[)`),
+			rule.Multiline(`^\s*(?:(?:abi|dep|fn|impl|mod|pub|trait)\s|#\[)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`^\s*<\?xml\s+version`),
+			rule.Multiline(`^\s*<\?xml\s+version`),
 		),
 	},
 	".t": &Heuristics{
@@ -1816,26 +1813,26 @@ var ContentHeuristics = map[string]*Heuristics{
 			rule.MatchingLanguages("Perl"),
 			rule.Not(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`^\s*use\s+v6\b`),
+				rule.Multiline(`^\s*use\s+v6\b`),
 			),
 			rule.Or(
 				rule.MatchingLanguages(""),
-				regex.MustCompileMultiline(`\buse\s+(?:strict\b|v?5\b)|^\s*use\s+(?:constant|overload)\b|^\s*(?:\*|(?:our\s*)?@)EXPORT\s*=|^\s*package\s+[^\W\d]\w*(?:::\w+)*\s*(?:[;{]|\sv?\d)|[\s$][^\W\d]\w*(?::\w+)*->[a-zA-Z_\[({]`),
+				rule.Multiline(`\buse\s+(?:strict\b|v?5\b)|^\s*use\s+(?:constant|overload)\b|^\s*(?:\*|(?:our\s*)?@)EXPORT\s*=|^\s*package\s+[^\W\d]\w*(?:::\w+)*\s*(?:[;{]|\sv?\d)|[\s$][^\W\d]\w*(?::\w+)*->[a-zA-Z_\[({]`),
 			),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Raku"),
-			regex.MustCompileMultiline(`^\s*(?:use\s+v6\b|\bmodule\b|\bmy\s+class\b)`),
+			rule.Multiline(`^\s*(?:use\s+v6\b|\bmodule\b|\bmy\s+class\b)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Turing"),
-			regex.MustCompileMultiline(`^\s*%[ \t]+|^\s*var\s+\w+(\s*:\s*\w+)?\s*:=\s*\w+`),
+			rule.Multiline(`^\s*%[ \t]+|^\s*var\s+\w+(\s*:\s*\w+)?\s*:=\s*\w+`),
 		),
 	},
 	".tact": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("JSON"),
-			regex.MustCompileMultiline(`\A\s*\{\"`),
+			rule.Multiline(`\A\s*\{\"`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Tact"),
@@ -1844,29 +1841,29 @@ var ContentHeuristics = map[string]*Heuristics{
 	".tag": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Java Server Pages"),
-			regex.MustCompileMultiline(`<%[@!=\s]?\s*(taglib|tag|include|attribute|variable)\s`),
+			rule.Multiline(`<%[@!=\s]?\s*(taglib|tag|include|attribute|variable)\s`),
 		),
 	},
 	".tlv": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("TL-Verilog"),
-			regex.MustCompileMultiline(`^\\.{0,10}TLV_version`),
+			rule.Multiline(`^\\.{0,10}TLV_version`),
 		),
 	},
 	".toc": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("World of Warcraft Addon Data"),
-			regex.MustCompileMultiline(`^## |@no-lib-strip@`),
+			rule.Multiline(`^## |@no-lib-strip@`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("TeX"),
-			regex.MustCompileMultiline(`^\\(contentsline|defcounter|beamer|boolfalse)`),
+			rule.Multiline(`^\\(contentsline|defcounter|beamer|boolfalse)`),
 		),
 	},
 	".ts": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`<TS\b`),
+			rule.Multiline(`<TS\b`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("TypeScript"),
@@ -1875,17 +1872,17 @@ var ContentHeuristics = map[string]*Heuristics{
 	".tsp": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("TypeSpec"),
-			regex.MustCompileMultiline(`^(import|using|namespace|interface|op|model|scalar|alias|union|enum)\s`),
+			rule.Multiline(`^(import|using|namespace|interface|op|model|scalar|alias|union|enum)\s`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("TSPLIB data"),
-			regex.MustCompileMultiline(`^(NAME|TYPE|DIMENSION|EDGE_WEIGHT_TYPE|EDGE_WEIGHT_FORMAT)\s*:`),
+			rule.Multiline(`^(NAME|TYPE|DIMENSION|EDGE_WEIGHT_TYPE|EDGE_WEIGHT_FORMAT)\s*:`),
 		),
 	},
 	".tst": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("GAP"),
-			regex.MustCompileMultiline(`gap> `),
+			rule.Multiline(`gap> `),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Scilab"),
@@ -1894,7 +1891,7 @@ var ContentHeuristics = map[string]*Heuristics{
 	".tsx": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("XML"),
-			regex.MustCompileMultiline(`(?i:^\s*<\?xml\s+version)`),
+			rule.Multiline(`(?i:^\s*<\?xml\s+version)`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("TSX"),
@@ -1903,11 +1900,11 @@ var ContentHeuristics = map[string]*Heuristics{
 	".txt": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Vim Help File"),
-			regex.MustCompileRuby(`(?:(?:^|[ \t])(?:vi|Vi(?=m))(?:m[<=>]?[0-9]+|m)?|[ \t]ex)(?=:(?=[ \t]*set?[ \t][^\r\n:]+:)|:(?![ \t]*set?[ \t]))(?:(?:[ \t]*:[ \t]*|[ \t])\w*(?:[ \t]*=(?:[^\\\s]|\\.)*)?)*[ \t:](?:filetype|ft|syntax)[ \t]*=(help)(?=$|\s|:)`),
+			rule.Ruby(`(?:(?:^|[ \t])(?:vi|Vi(?=m))(?:m[<=>]?[0-9]+|m)?|[ \t]ex)(?=:(?=[ \t]*set?[ \t][^\r\n:]+:)|:(?![ \t]*set?[ \t]))(?:(?:[ \t]*:[ \t]*|[ \t])\w*(?:[ \t]*=(?:[^\\\s]|\\.)*)?)*[ \t:](?:filetype|ft|syntax)[ \t]*=(help)(?=$|\s|:)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Hosts File"),
-			regex.MustCompileRuby(`(?xi) ^
+			rule.Ruby(`(?xi) ^
 
 # IPv4 address
 (?<ipv4>
@@ -1934,7 +1931,7 @@ var ContentHeuristics = map[string]*Heuristics{
 		),
 		rule.Or(
 			rule.MatchingLanguages("Adblock Filter List"),
-			regex.MustCompileRuby(`(?x)\A
+			rule.Ruby(`(?x)\A
 \[
 (?<version>
   (?:
@@ -1961,7 +1958,7 @@ var ContentHeuristics = map[string]*Heuristics{
 	".typ": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Typst"),
-			regex.MustCompileMultiline(`^#(import|show|let|set)`),
+			rule.Multiline(`^#(import|show|let|set)`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("XML"),
@@ -1970,27 +1967,27 @@ var ContentHeuristics = map[string]*Heuristics{
 	".url": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("INI"),
-			regex.MustCompileRuby(`^\[InternetShortcut\](?:\r?\n|\r)(?>[^\s\[][^\r\n]*(?:\r?\n|\r))*URL=`),
+			rule.Ruby(`^\[InternetShortcut\](?:\r?\n|\r)(?>[^\s\[][^\r\n]*(?:\r?\n|\r))*URL=`),
 		),
 	},
 	".v": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Coq"),
-			regex.MustCompileMultiline(`(?:^|\s)(?:Proof|Qed)\.(?:$|\s)|(?:^|\s)Require[ \t]+(Import|Export)\s`),
+			rule.Multiline(`(?:^|\s)(?:Proof|Qed)\.(?:$|\s)|(?:^|\s)Require[ \t]+(Import|Export)\s`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Verilog"),
-			regex.MustCompileMultiline(`^[ \t]*module\s+[^\s()]+\s+\#?\(|^[ \t]*`+"`"+`(?:define|ifdef|ifndef|include|timescale)|^[ \t]*always[ \t]+@|^[ \t]*initial[ \t]+(begin|@)`),
+			rule.Multiline(`^[ \t]*module\s+[^\s()]+\s+\#?\(|^[ \t]*`+"`"+`(?:define|ifdef|ifndef|include|timescale)|^[ \t]*always[ \t]+@|^[ \t]*initial[ \t]+(begin|@)`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("V"),
-			regex.MustCompileMultiline(`\$(?:if|else)[ \t]|^[ \t]*fn\s+[^\s()]+\(.*?\).*?\{|^[ \t]*for\s*\{`),
+			rule.Multiline(`\$(?:if|else)[ \t]|^[ \t]*fn\s+[^\s()]+\(.*?\).*?\{|^[ \t]*for\s*\{`),
 		),
 	},
 	".vba": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("Vim Script"),
-			regex.MustCompileMultiline(`^UseVimball`),
+			rule.Multiline(`^UseVimball`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("VBA"),
@@ -1999,53 +1996,53 @@ var ContentHeuristics = map[string]*Heuristics{
 	".vcf": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("TSV"),
-			regex.MustCompileMultiline(`\A##fileformat=VCF`),
+			rule.Multiline(`\A##fileformat=VCF`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("vCard"),
-			regex.MustCompileMultiline(`\ABEGIN:VCARD`),
+			rule.Multiline(`\ABEGIN:VCARD`),
 		),
 	},
 	".w": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("OpenEdge ABL"),
-			regex.MustCompileMultiline(`&ANALYZE-SUSPEND _UIB-CODE-BLOCK _CUSTOM _DEFINITIONS`),
+			rule.Multiline(`&ANALYZE-SUSPEND _UIB-CODE-BLOCK _CUSTOM _DEFINITIONS`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("CWeb"),
-			regex.MustCompileMultiline(`^@(<|\w+\.)`),
+			rule.Multiline(`^@(<|\w+\.)`),
 		),
 	},
 	".x": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("DirectX 3D File"),
-			regex.MustCompileMultiline(`^xof 030(2|3)(?:txt|bin|tzip|bzip)\b`),
+			rule.Multiline(`^xof 030(2|3)(?:txt|bin|tzip|bzip)\b`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("RPC"),
-			regex.MustCompileMultiline(`\b(program|version)\s+\w+\s*\{|\bunion\s+\w+\s+switch\s*\(`),
+			rule.Multiline(`\b(program|version)\s+\w+\s*\{|\bunion\s+\w+\s+switch\s*\(`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Logos"),
-			regex.MustCompileMultiline(`^%(end|ctor|hook|group)\b`),
+			rule.Multiline(`^%(end|ctor|hook|group)\b`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("Linker Script"),
-			regex.MustCompileMultiline(`OUTPUT_ARCH\(|OUTPUT_FORMAT\(|SECTIONS`),
+			rule.Multiline(`OUTPUT_ARCH\(|OUTPUT_FORMAT\(|SECTIONS`),
 		),
 	},
 	".yaml": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("MiniYAML"),
-			regex.MustCompileMultiline(`^\t+.*?[^\s:].*?:`),
+			rule.Multiline(`^\t+.*?[^\s:].*?:`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("OASv2-yaml"),
-			regex.MustCompileMultiline(`swagger:\s?'?"?2.[0-9.]+'?"?`),
+			rule.Multiline(`swagger:\s?'?"?2.[0-9.]+'?"?`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("OASv3-yaml"),
-			regex.MustCompileMultiline(`openapi:\s?'?"?3.[0-9.]+'?"?`),
+			rule.Multiline(`openapi:\s?'?"?3.[0-9.]+'?"?`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("YAML"),
@@ -2054,15 +2051,15 @@ var ContentHeuristics = map[string]*Heuristics{
 	".yml": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("MiniYAML"),
-			regex.MustCompileMultiline(`^\t+.*?[^\s:].*?:`),
+			rule.Multiline(`^\t+.*?[^\s:].*?:`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("OASv2-yaml"),
-			regex.MustCompileMultiline(`swagger:\s?'?"?2.[0-9.]+'?"?`),
+			rule.Multiline(`swagger:\s?'?"?2.[0-9.]+'?"?`),
 		),
 		rule.Or(
 			rule.MatchingLanguages("OASv3-yaml"),
-			regex.MustCompileMultiline(`openapi:\s?'?"?3.[0-9.]+'?"?`),
+			rule.Multiline(`openapi:\s?'?"?3.[0-9.]+'?"?`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("YAML"),
@@ -2071,7 +2068,7 @@ var ContentHeuristics = map[string]*Heuristics{
 	".yy": &Heuristics{
 		rule.Or(
 			rule.MatchingLanguages("JSON"),
-			regex.MustCompileMultiline(`\A\s*[{\[]`),
+			rule.Multiline(`\A\s*[{\[]`),
 		),
 		rule.Always(
 			rule.MatchingLanguages("Yacc"),
@@ -5,23 +5,47 @@ package data
 
 import "github.com/go-enry/go-enry/v2/regex"
 
-var DocumentationMatchers = []regex.EnryRegexp{
-	regex.MustCompile(`^[Dd]ocs?/`),
-	regex.MustCompile(`(^|/)[Dd]ocumentation/`),
-	regex.MustCompile(`(^|/)[Gg]roovydoc/`),
-	regex.MustCompile(`(^|/)[Jj]avadoc/`),
-	regex.MustCompile(`^[Mm]an/`),
-	regex.MustCompile(`^[Ee]xamples/`),
-	regex.MustCompile(`^[Dd]emos?/`),
-	regex.MustCompile(`(^|/)inst/doc/`),
-	regex.MustCompile(`(^|/)CITATION(\.cff|(S)?(\.(bib|md))?)$`),
-	regex.MustCompile(`(^|/)CHANGE(S|LOG)?(\.|$)`),
-	regex.MustCompile(`(^|/)CONTRIBUTING(\.|$)`),
-	regex.MustCompile(`(^|/)COPYING(\.|$)`),
-	regex.MustCompile(`(^|/)INSTALL(\.|$)`),
-	regex.MustCompile(`(^|/)LICEN[CS]E(\.|$)`),
-	regex.MustCompile(`(^|/)[Ll]icen[cs]e(\.|$)`),
-	regex.MustCompile(`(^|/)README(\.|$)`),
-	regex.MustCompile(`(^|/)[Rr]eadme(\.|$)`),
-	regex.MustCompile(`^[Ss]amples?/`),
+// DocumentationMatchers are compiled on their first use, see regex.LazyRegexp.
+var DocumentationMatchers = []*regex.LazyRegexp{
+	regex.Lazy(`^[Dd]ocs?/`),
+	regex.Lazy(`(^|/)[Dd]ocumentation/`),
+	regex.Lazy(`(^|/)[Gg]roovydoc/`),
+	regex.Lazy(`(^|/)[Jj]avadoc/`),
+	regex.Lazy(`^[Mm]an/`),
+	regex.Lazy(`^[Ee]xamples/`),
+	regex.Lazy(`^[Dd]emos?/`),
+	regex.Lazy(`(^|/)inst/doc/`),
+	regex.Lazy(`(^|/)CITATION(\.cff|(S)?(\.(bib|md))?)$`),
+	regex.Lazy(`(^|/)CHANGE(S|LOG)?(\.|$)`),
+	regex.Lazy(`(^|/)CONTRIBUTING(\.|$)`),
+	regex.Lazy(`(^|/)COPYING(\.|$)`),
+	regex.Lazy(`(^|/)INSTALL(\.|$)`),
+	regex.Lazy(`(^|/)LICEN[CS]E(\.|$)`),
+	regex.Lazy(`(^|/)[Ll]icen[cs]e(\.|$)`),
+	regex.Lazy(`(^|/)README(\.|$)`),
+	regex.Lazy(`(^|/)[Rr]eadme(\.|$)`),
+	regex.Lazy(`^[Ss]amples?/`),
 }
+
+// DocumentationSet is equivalent to matching any of the DocumentationMatchers, and tells which one matched.
+// Each of them comes with a literal all of its matches contain, used as a prefilter.
+var DocumentationSet = regex.NewSet(DocumentationMatchers, []string{
+	`oc`,
+	`ocumentation/`,
+	`roovydoc/`,
+	`avadoc/`,
+	`an/`,
+	`xamples/`,
+	`emo`,
+	`inst/doc/`,
+	`CITATION`,
+	`CHANGE`,
+	`CONTRIBUTING`,
+	`COPYING`,
+	`INSTALL`,
+	`LICEN`,
+	`icen`,
+	`README`,
+	`eadme`,
+	`ample`,
+})
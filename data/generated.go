@@ -21,7 +21,7 @@ var GeneratedCodeExtensions = map[string]struct{}{
 type GeneratedCodeNameMatcher func(string) bool
 
 func nameMatches(pattern string) GeneratedCodeNameMatcher {
-	r := regex.MustCompile(pattern)
+	r := regex.Lazy(pattern)
 	return func(name string) bool {
 		return r.MatchString(name)
 	}
@@ -199,7 +199,7 @@ func isMinifiedFile(path, ext string, content []byte) bool {
 	return chars/lines > 110
 }
 
-var sourceMapRegex = regex.MustCompile(`^\/[*\/][\#@] source(?:Mapping)?URL|sourceURL=`)
+var sourceMapRegex = regex.Lazy(`^\/[*\/][\#@] source(?:Mapping)?URL|sourceURL=`)
 
 // hasSourceMapReference returns whether the file contains a reference to a
 // source-map file.
@@ -217,9 +217,9 @@ func hasSourceMapReference(_ string, ext string, content []byte) bool {
 	return false
 }
 
-var sourceMapRegexps = []regex.EnryRegexp{
-	regex.MustCompile(`^{"version":\d+,`),
-	regex.MustCompile(`^\/\*\* Begin line maps\. \*\*\/{`),
+var sourceMapRegexps = []*regex.LazyRegexp{
+	regex.Lazy(`^{"version":\d+,`),
+	regex.Lazy(`^\/\*\* Begin line maps\. \*\*\/{`),
 }
 
 // isSourceMap returns whether the file itself is a source map.
@@ -291,7 +291,7 @@ func isGeneratedNetDocfile(_, ext string, content []byte) bool {
 		bytes.Contains(lines[len(lines)-2], []byte("</doc>"))
 }
 
-var pegJavaScriptGeneratedRegex = regex.MustCompile(`^(?:[^\/]|\/[^\*])*\/\*(?:[^\*]|\*[^\/])*Generated by PEG.js`)
+var pegJavaScriptGeneratedRegex = regex.Lazy(`^(?:[^\/]|\/[^\*])*\/\*(?:[^\*]|\*[^\/])*Generated by PEG.js`)
 
 func isGeneratedJavaScriptPEGParser(_, ext string, content []byte) bool {
 	if ext != ".js" {
@@ -303,11 +303,11 @@ func isGeneratedJavaScriptPEGParser(_, ext string, content []byte) bool {
 	return pegJavaScriptGeneratedRegex.Match(bytes.Join(getLines(content, 5), []byte("")))
 }
 
-var postScriptType1And42Regex = regex.MustCompile(`(\n|\r\n|\r)\s*(?:currentfile eexec\s+|\/sfnts\s+\[)`)
+var postScriptType1And42Regex = regex.Lazy(`(\n|\r\n|\r)\s*(?:currentfile eexec\s+|\/sfnts\s+\[)`)
 
-var postScriptRegexes = []regex.EnryRegexp{
-	regex.MustCompile(`[0-9]|draw|mpage|ImageMagick|inkscape|MATLAB`),
-	regex.MustCompile(`PCBNEW|pnmtops|\(Unknown\)|Serif Affinity|Filterimage -tops`),
+var postScriptRegexes = []*regex.LazyRegexp{
+	regex.Lazy(`[0-9]|draw|mpage|ImageMagick|inkscape|MATLAB`),
+	regex.Lazy(`PCBNEW|pnmtops|\(Unknown\)|Serif Affinity|Filterimage -tops`),
 }
 
 func isGeneratedPostScript(_, ext string, content []byte) bool {
@@ -611,7 +611,7 @@ func isGeneratedGRPCCpp(_, ext string, content []byte) bool {
 	}
 }
 
-var dartRegex = regex.MustCompile(`generated code\W{2,3}do not modify`)
+var dartRegex = regex.Lazy(`generated code\W{2,3}do not modify`)
 
 func isGeneratedDart(_, ext string, content []byte) bool {
 	if ext != ".dart" {
@@ -640,8 +640,8 @@ func isGeneratedPerlPPPortHeader(name, _ string, content []byte) bool {
 }
 
 var (
-	gameMakerStudioFirstLineRegex = regex.MustCompile(`^\d\.\d\.\d.+\|\{`)
-	gameMakerStudioThirdLineRegex = regex.MustCompile(`\"modelName\"\:\s*\"GM`)
+	gameMakerStudioFirstLineRegex = regex.Lazy(`^\d\.\d\.\d.+\|\{`)
+	gameMakerStudioThirdLineRegex = regex.Lazy(`\"modelName\"\:\s*\"GM`)
 )
 
 func isGeneratedGameMakerStudio(_, ext string, content []byte) bool {
@@ -658,9 +658,9 @@ func isGeneratedGameMakerStudio(_, ext string, content []byte) bool {
 		gameMakerStudioFirstLineRegex.Match(lines[0])
 }
 
-var gimpRegexes = []regex.EnryRegexp{
-	regex.MustCompile(`\/\* GIMP [a-zA-Z0-9\- ]+ C\-Source image dump \(.+?\.c\) \*\/`),
-	regex.MustCompile(`\/\*  GIMP header image file format \([a-zA-Z0-9\- ]+\)\: .+?\.h  \*\/`),
+var gimpRegexes = []*regex.LazyRegexp{
+	regex.Lazy(`\/\* GIMP [a-zA-Z0-9\- ]+ C\-Source image dump \(.+?\.c\) \*\/`),
+	regex.Lazy(`\/\*  GIMP header image file format \([a-zA-Z0-9\- ]+\)\: .+?\.h  \*\/`),
 }
 
 func isGeneratedGimp(_, ext string, content []byte) bool {
@@ -722,10 +722,10 @@ func isGeneratedHaxe(_, ext string, content []byte) bool {
 }
 
 var (
-	doxygenRegex         = regex.MustCompile(`<!--\s+Generated by Doxygen\s+[.0-9]+\s*-->`)
-	htmlMetaRegex        = regex.MustCompile(`<meta(\s+[^>]+)>`)
-	htmlMetaContentRegex = regex.MustCompile(`\s+(name|content|value)\s*=\s*("[^"]+"|'[^']+'|[^\s"']+)`)
-	orgModeMetaRegex     = regex.MustCompile(`org\s+mode`)
+	doxygenRegex         = regex.Lazy(`<!--\s+Generated by Doxygen\s+[.0-9]+\s*-->`)
+	htmlMetaRegex        = regex.Lazy(`<meta(\s+[^>]+)>`)
+	htmlMetaContentRegex = regex.Lazy(`\s+(name|content|value)\s*=\s*("[^"]+"|'[^']+'|[^\s"']+)`)
+	orgModeMetaRegex     = regex.Lazy(`org\s+mode`)
 )
 
 func isGeneratedHTML(_, ext string, content []byte) bool {
@@ -761,14 +761,14 @@ func isGeneratedHTML(_, ext string, content []byte) bool {
 	part := bytes.ToLower(bytes.Join(lines, []byte{' '}))
 	part = bytes.ReplaceAll(part, []byte{'\n'}, []byte{})
 	part = bytes.ReplaceAll(part, []byte{'\r'}, []byte{})
-	matches := htmlMetaRegex.FindAll(part, -1)
+	matches := htmlMetaRegex.Regexp().FindAll(part, -1)
 	if len(matches) == 0 {
 		return false
 	}
 
 	for _, m := range matches {
 		var name, value, content string
-		ms := htmlMetaContentRegex.FindAllStringSubmatch(string(m), -1)
+		ms := htmlMetaContentRegex.Regexp().FindAllStringSubmatch(string(m), -1)
 		for _, m := range ms {
 			switch m[1] {
 			case "name":
@@ -1,6 +1,11 @@
 package data
 
-import "github.com/go-enry/go-enry/v2/data/rule"
+import (
+	"fmt"
+
+	"github.com/go-enry/go-enry/v2/data/rule"
+	"github.com/go-enry/go-enry/v2/regex"
+)
 
 // Heuristics implements a rule-based content matching engine.
 
@@ -29,6 +34,36 @@ func (hs Heuristics) Match(data []byte) []string {
 	return matchedLangs
 }
 
+// CompileContentHeuristics returns a copy of ContentHeuristics with all the
+// rules re-compiled for the given regex engine.
+func CompileContentHeuristics(engine regex.Engine) (map[string]*Heuristics, error) {
+	compiled := make(map[string]*Heuristics, len(ContentHeuristics))
+	for ext, hs := range ContentHeuristics {
+		c, err := hs.Compile(engine)
+		if err != nil {
+			return nil, fmt.Errorf("heuristics for %q: %w", ext, err)
+		}
+		compiled[ext] = c
+	}
+
+	return compiled, nil
+}
+
+// Compile returns a copy of the heuristics with all the rules re-compiled
+// for the given regex engine.
+func (hs Heuristics) Compile(engine regex.Engine) (*Heuristics, error) {
+	compiled := make(Heuristics, 0, len(hs))
+	for _, h := range hs {
+		c, err := rule.Compile(h, engine)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, c)
+	}
+
+	return &compiled, nil
+}
+
 // matchString is a convenience used only in tests.
 func (hs *Heuristics) matchString(data string) []string {
 	return hs.Match([]byte(data))
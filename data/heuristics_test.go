@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/go-enry/go-enry/v2/data/rule"
+	"github.com/go-enry/go-enry/v2/regex"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,3 +60,15 @@ func TestContentHeuristic_MatchingOr(t *testing.T) {
 	lang := testContentHeuristics[".ms"].matchString("	.include \"math.s\"")
 	assert.Equal(t, []string{"Unix Assembly"}, lang)
 }
+
+func TestCompileContentHeuristics(t *testing.T) {
+	engine, err := regex.Lookup(regex.RE2)
+	assert.NoError(t, err)
+
+	heuristics, err := CompileContentHeuristics(engine)
+	assert.NoError(t, err)
+	assert.Equal(t, len(ContentHeuristics), len(heuristics))
+
+	const content = "	.include \"math.s\""
+	assert.Equal(t, ContentHeuristics[".ms"].matchString(content), heuristics[".ms"].matchString(content))
+}
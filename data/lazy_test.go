@@ -0,0 +1,74 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/go-enry/go-enry/v2/data/rule"
+	"github.com/go-enry/go-enry/v2/regex"
+)
+
+// recordingEngine collects the multi-line expressions compiled through it.
+type recordingEngine struct {
+	regex.Engine
+	multiline []string
+}
+
+func (e *recordingEngine) CompileMultiline(expr string) (regex.Regexp, error) {
+	e.multiline = append(e.multiline, expr)
+	return e.Engine.CompileMultiline(expr)
+}
+
+// generatedSources returns the sources of the generated path and content
+// regexps, all of which used to be compiled at package init.
+func generatedSources(b *testing.B) (paths, contents []string) {
+	for _, matchers := range [][]*regex.LazyRegexp{VendorMatchers, DocumentationMatchers, TestMatchers} {
+		for _, m := range matchers {
+			paths = append(paths, m.String())
+		}
+	}
+	paths = append(paths, FastVendorMatcher.String())
+
+	engine := &recordingEngine{Engine: regex.Default()}
+	if _, err := CompileContentHeuristics(engine); err != nil {
+		b.Fatal(err)
+	}
+
+	return paths, engine.multiline
+}
+
+var (
+	regexpSink  regex.EnryRegexp
+	lazySink    *regex.LazyRegexp
+	patternSink *rule.Pattern
+)
+
+// BenchmarkInit compares the cost of the generated regexps at package init,
+// when all of them were compiled, to their cost now that they compile on
+// first use. The difference is the time saved at init.
+func BenchmarkInit(b *testing.B) {
+	paths, contents := generatedSources(b)
+
+	b.Run("eager", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, expr := range paths {
+				regexpSink = regex.MustCompile(expr)
+			}
+			for _, expr := range contents {
+				regexpSink = regex.MustCompileMultiline(expr)
+			}
+		}
+	})
+
+	b.Run("lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, expr := range paths {
+				lazySink = regex.Lazy(expr)
+			}
+			for _, expr := range contents {
+				patternSink = rule.Multiline(expr)
+			}
+		}
+	})
+}
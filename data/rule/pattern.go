@@ -0,0 +1,154 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-enry/go-enry/v2/regex"
+)
+
+type syntax int
+
+const (
+	multiline syntax = iota
+	ruby
+)
+
+// Pattern is a Matcher for a regular expression that keeps its source,
+// so that the rules using it can be re-compiled for another regex.Engine.
+//
+// Patterns returned by Multiline and Ruby are compiled on their first use,
+// which is safe for concurrent use by multiple goroutines.
+//
+// A Pattern with a syntax not supported by its engine never matches and
+// is skipped by the rules, same as a nil regex.EnryRegexp.
+type Pattern struct {
+	expr   string
+	syntax syntax
+	once   sync.Once
+	re     regex.Regexp
+}
+
+// Multiline returns a Pattern in multi-line mode for the default engine.
+func Multiline(expr string) *Pattern {
+	return &Pattern{expr: expr, syntax: multiline}
+}
+
+// Ruby returns a Pattern using syntax that only some engines support,
+// for the default engine.
+func Ruby(expr string) *Pattern {
+	return &Pattern{expr: expr, syntax: ruby}
+}
+
+// regexp returns the compiled expression, compiling it with the default
+// engine on the first call. It panics if the expression does not compile.
+func (p *Pattern) regexp() regex.Regexp {
+	p.once.Do(func() {
+		re, err := p.compile(regex.Default())
+		if err != nil {
+			panic(err)
+		}
+		p.re = re
+	})
+	return p.re
+}
+
+// Compile returns a copy of the Pattern compiled with the given engine.
+// Unlike Multiline and Ruby, it compiles the expression right away.
+func (p *Pattern) Compile(engine regex.Engine) (*Pattern, error) {
+	re, err := p.compile(engine)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &Pattern{expr: p.expr, syntax: p.syntax}
+	compiled.once.Do(func() {
+		compiled.re = re
+	})
+	return compiled, nil
+}
+
+func (p *Pattern) compile(engine regex.Engine) (regex.Regexp, error) {
+	var re regex.Regexp
+	var err error
+	switch p.syntax {
+	case multiline:
+		re, err = engine.CompileMultiline(p.expr)
+	case ruby:
+		re, err = engine.CompileRuby(p.expr)
+	}
+
+	if errors.Is(err, regex.ErrUnsupportedSyntax) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("rule: compiling %q with %s: %w", p.expr, engine.Name(), err)
+	}
+
+	return re, nil
+}
+
+// Match implements Matcher.
+func (p *Pattern) Match(data []byte) bool {
+	re := p.regexp()
+	return re != nil && re.Match(data)
+}
+
+// String returns the source of the regular expression.
+func (p *Pattern) String() string {
+	return p.expr
+}
+
+// Compile returns a copy of the heuristic with all its Patterns re-compiled
+// for the given engine. Other kinds of Matchers are kept as is.
+func Compile(h Heuristic, engine regex.Engine) (Heuristic, error) {
+	switch r := h.(type) {
+	case or:
+		p, err := compileMatcher(r.pattern, engine)
+		if err != nil {
+			return nil, err
+		}
+		return or{r.languages, p}, nil
+	case and:
+		ps, err := compileMatchers(r.patterns, engine)
+		if err != nil {
+			return nil, err
+		}
+		return and{r.languages, ps}, nil
+	case not:
+		ps, err := compileMatchers(r.Patterns, engine)
+		if err != nil {
+			return nil, err
+		}
+		return not{r.languages, ps}, nil
+	}
+
+	return h, nil
+}
+
+func compileMatchers(ms []Matcher, engine regex.Engine) ([]Matcher, error) {
+	compiled := make([]Matcher, 0, len(ms))
+	for _, m := range ms {
+		c, err := compileMatcher(m, engine)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, c)
+	}
+
+	return compiled, nil
+}
+
+func compileMatcher(m Matcher, engine regex.Engine) (Matcher, error) {
+	switch v := m.(type) {
+	case *Pattern:
+		return v.Compile(engine)
+	case Heuristic:
+		// nested rules of an And
+		return Compile(v, engine)
+	}
+
+	return m, nil
+}
@@ -55,7 +55,7 @@ func Or(l languages, p Matcher) Heuristic {
 
 // Match implements rule.Matcher.
 func (r or) Match(data []byte) bool {
-	if runOnRE2AndRegexNotAccepted(r.pattern) {
+	if regexNotAccepted(r.pattern) {
 		return false
 	}
 	return r.pattern.Match(data)
@@ -75,7 +75,7 @@ func And(l languages, m ...Matcher) Heuristic {
 // Match implements data.Matcher.
 func (r and) Match(data []byte) bool {
 	for _, p := range r.patterns {
-		if runOnRE2AndRegexNotAccepted(p) {
+		if regexNotAccepted(p) {
 			continue
 		}
 		if !p.Match(data) {
@@ -99,7 +99,7 @@ func Not(l languages, r ...Matcher) Heuristic {
 // Match implements data.Matcher.
 func (r not) Match(data []byte) bool {
 	for _, p := range r.Patterns {
-		if runOnRE2AndRegexNotAccepted(p) {
+		if regexNotAccepted(p) {
 			continue
 		}
 		if p.Match(data) {
@@ -124,10 +124,15 @@ func (r always) Match(data []byte) bool {
 	return true
 }
 
-// Checks if a regex syntax isn't accepted by RE2 engine.
-// It's nil by construction from regex.MustCompileRuby but
+// Checks if a regex syntax isn't accepted by the engine it was compiled with.
+// On RE2 it's nil by construction from regex.MustCompileRuby but
 // is used here as a Matcher interface wich itself is non-nil.
-func runOnRE2AndRegexNotAccepted(re Matcher) bool {
-	v, ok := re.(regex.EnryRegexp)
-	return ok && v == nil
+func regexNotAccepted(m Matcher) bool {
+	switch v := m.(type) {
+	case regex.EnryRegexp:
+		return v == nil
+	case *Pattern:
+		return v.regexp() == nil
+	}
+	return false
 }
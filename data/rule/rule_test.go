@@ -69,3 +69,25 @@ func check(t *testing.T, f fixture) {
 			"'%s' is expected NOT to .Match() by rule %s%v", f.noMatch, f.name, f.rule)
 	}
 }
+
+func TestPatternCompile(t *testing.T) {
+	re2, err := regex.Lookup(regex.RE2)
+	assert.NoError(t, err)
+
+	h := And(MatchingLanguages(lang),
+		Or(noLanguages(), Multiline(`^a`)),
+		Not(noLanguages(), Ruby(`(?<!x)b`)),
+	)
+	compiled, err := Compile(h, re2)
+	assert.NoError(t, err)
+	check(t, fixture{"CompiledAnd", compiled, 1, "c\na", "c"})
+
+	p, err := Multiline(`^a`).Compile(re2)
+	assert.NoError(t, err)
+	assert.Equal(t, `^a`, p.String())
+
+	p, err = Ruby(`(?<!x)b`).Compile(re2)
+	assert.NoError(t, err)
+	assert.False(t, p.Match([]byte("b")), "unsupported syntax must never match")
+	assert.True(t, regexNotAccepted(p))
+}
@@ -3,15 +3,30 @@ package data
 import "github.com/go-enry/go-enry/v2/regex"
 
 // TestMatchers is hand made collection of regexp used by the function `enry.IsTest`
-// to identify test files in different languages.
-var TestMatchers = []regex.EnryRegexp{
-	regex.MustCompile(`(^|/)tests/.*Test\.php$`),
-	regex.MustCompile(`(^|/)test/.*Test(s?)\.java$`),
-	regex.MustCompile(`(^|/)test(/|/.*/)Test.*\.java$`),
-	regex.MustCompile(`(^|/)test/.*(Test(s?)|Spec(s?))\.scala$`),
-	regex.MustCompile(`(^|/)test_.*\.py$`),
-	regex.MustCompile(`(^|/).*_test\.go$`),
-	regex.MustCompile(`(^|/).*_(test|spec)\.rb$`),
-	regex.MustCompile(`(^|/).*Test(s?)\.cs$`),
-	regex.MustCompile(`(^|/).*\.(test|spec)\.(ts|tsx|js)$`),
+// to identify test files in different languages, through TestSet. They are
+// compiled on their first use, see regex.LazyRegexp.
+var TestMatchers = []*regex.LazyRegexp{
+	regex.Lazy(`(^|/)tests/.*Test\.php$`),
+	regex.Lazy(`(^|/)test/.*Test(s?)\.java$`),
+	regex.Lazy(`(^|/)test(/|/.*/)Test.*\.java$`),
+	regex.Lazy(`(^|/)test/.*(Test(s?)|Spec(s?))\.scala$`),
+	regex.Lazy(`(^|/)test_.*\.py$`),
+	regex.Lazy(`(^|/).*_test\.go$`),
+	regex.Lazy(`(^|/).*_(test|spec)\.rb$`),
+	regex.Lazy(`(^|/).*Test(s?)\.cs$`),
+	regex.Lazy(`(^|/).*\.(test|spec)\.(ts|tsx|js)$`),
 }
+
+// TestSet is equivalent to matching any of the TestMatchers, and tells which one matched.
+// Each of them comes with a literal all of its matches contain, used as a prefilter.
+var TestSet = regex.NewSet(TestMatchers, []string{
+	`Test.php`,
+	`test/`,
+	`.java`,
+	`.scala`,
+	`test_`,
+	`_test.go`,
+	`.rb`,
+	`Test`,
+	`.`,
+})
@@ -0,0 +1,151 @@
+package enry
+
+// Status tells how the language of a Detection was resolved, and so how
+// much it can be trusted.
+type Status int
+
+const (
+	// Undetected means no strategy returned any language, e.g. for binary content.
+	Undetected Status = iota
+	// Definitive means a strategy before the content heuristics, e.g. by
+	// file name, extension or shebang, returned a single language.
+	Definitive
+	// Heuristic means the content heuristics picked the language among the
+	// candidates left by the other strategies.
+	Heuristic
+	// Classified means the classifier picked the language among the
+	// candidates, with the probability in the Score of the Detection.
+	Classified
+	// Ambiguous means several candidates are left and the classifier can't
+	// tell them apart, e.g. because there is no content. The language is
+	// an arbitrary pick among them.
+	Ambiguous
+)
+
+var statusNames = [...]string{
+	Undetected: "undetected",
+	Definitive: "definitive",
+	Heuristic:  "heuristic",
+	Classified: "classified",
+	Ambiguous:  "ambiguous",
+}
+
+func (s Status) String() string {
+	if s < 0 || int(s) >= len(statusNames) {
+		return "unknown"
+	}
+	return statusNames[s]
+}
+
+// Detection is the language detected for a file, with how it was resolved.
+type Detection struct {
+	// Language is the most probable language, same as GetLanguage returns.
+	Language string
+	Status   Status
+	// Candidates are the languages left by the strategies, Language first,
+	// same as GetLanguages returns.
+	Candidates []string
+	// Score is the probability of Language according to the classifier,
+	// among the Candidates, if the Status is Classified or Ambiguous.
+	Score float64
+	// Strategy is the name of the strategy that resolved the Language:
+	// modeline, filename, shebang, extension, xml, manpage, heuristics or
+	// classifier. It is empty if the Status is Undetected.
+	Strategy string
+}
+
+// Certain returns the Language, or OtherLanguage if it is a guess: if the
+// Detection is Ambiguous, or Classified with a Score lower than minScore.
+func (r Detection) Certain(minScore float64) string {
+	switch r.Status {
+	case Ambiguous:
+		return OtherLanguage
+	case Classified:
+		if r.Score < minScore {
+			return OtherLanguage
+		}
+	}
+
+	return r.Language
+}
+
+// definitiveStrategies are the strategies run before the content
+// heuristics, by name. A single language returned by any of them is Definitive.
+var definitiveStrategies = []struct {
+	name     string
+	strategy Strategy
+}{
+	{"modeline", GetLanguagesByModeline},
+	{"filename", GetLanguagesByFilename},
+	{"shebang", GetLanguagesByShebang},
+	{"extension", GetLanguagesByExtension},
+	{"xml", GetLanguagesByXML},
+	{"manpage", GetLanguagesByManpage},
+}
+
+// defaultDetector is the Detector used by Detect. NewDetector can't fail without options.
+var defaultDetector, _ = NewDetector()
+
+// Detect runs the same strategies as GetLanguage with the default
+// configuration, and tells how the language was resolved, so that callers
+// can tell a definitive result from a guess among several candidates.
+func Detect(filename string, content []byte) Detection {
+	return defaultDetector.Detect(filename, content)
+}
+
+// Detect is the same as the package-level Detect, using the Detector
+// configuration. It doesn't use the cache of the Detector, which only
+// stores the candidates.
+func (d *Detector) Detect(filename string, content []byte) Detection {
+	if IsBinary(content) {
+		return Detection{Status: Undetected}
+	}
+
+	var languages []string
+	for _, s := range definitiveStrategies {
+		candidates := s.strategy(filename, content, languages)
+		if len(candidates) == 0 {
+			continue
+		}
+
+		if len(candidates) == 1 {
+			return Detection{Language: candidates[0], Status: Definitive, Candidates: candidates, Strategy: s.name}
+		}
+		languages = candidates
+	}
+
+	if candidates := d.GetLanguagesByContent(filename, content, languages); len(candidates) == 1 {
+		return Detection{Language: candidates[0], Status: Heuristic, Candidates: candidates, Strategy: "heuristics"}
+	} else if len(candidates) > 1 {
+		languages = candidates
+	}
+
+	if len(languages) == 0 {
+		return Detection{Status: Undetected}
+	}
+
+	return d.classifyDetection(content, languages)
+}
+
+// classifyDetection returns the Detection of content among several candidates by the classifier.
+func (d *Detector) classifyDetection(content []byte, candidates []string) Detection {
+	c, ok := d.classifier.(Classifier)
+	if !ok {
+		languages := d.GetLanguagesByClassifier("", content, candidates)
+		return Detection{Language: languages[0], Status: Classified, Candidates: languages, Strategy: "classifier"}
+	}
+
+	scoredLangs := rankCandidates(c, content, candidates)
+	r := Detection{
+		Language:   scoredLangs[0].language,
+		Status:     Classified,
+		Candidates: sortLanguagesByScore(scoredLangs),
+		Score:      scoredLangs[0].score,
+		Strategy:   "classifier",
+	}
+
+	if len(content) == 0 || r.Score == 0 || (len(scoredLangs) > 1 && scoredLangs[1].score == r.Score) {
+		r.Status = Ambiguous
+	}
+	return r
+}
@@ -0,0 +1,135 @@
+package enry
+
+import (
+	"testing"
+
+	"github.com/go-enry/go-enry/v2/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	d, err := NewDetector(WithClassifier(fixedClassifier{"Perl": 0.3, "Prolog": 0.6, "Raku": 0.1}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		expected Detection
+	}{
+		{
+			name:     "extension",
+			filename: "main.go",
+			content:  "package main",
+			expected: Detection{Language: "Go", Status: Definitive, Candidates: []string{"Go"}, Strategy: "extension"},
+		},
+		{
+			name:     "heuristics",
+			filename: "foo.h",
+			content:  "@interface Foo : NSObject\n@end\n",
+			expected: Detection{Language: "Objective-C", Status: Heuristic, Candidates: []string{"Objective-C"}, Strategy: "heuristics"},
+		},
+		{
+			name:     "classifier",
+			filename: "foo.pl",
+			content:  "print 1;\n",
+			expected: Detection{Language: "Prolog", Status: Classified, Candidates: []string{"Prolog", "Perl", "Raku"}, Score: 0.6, Strategy: "classifier"},
+		},
+		{
+			name:     "no content",
+			filename: "foo.pl",
+			expected: Detection{Language: "Prolog", Status: Ambiguous, Candidates: []string{"Prolog", "Perl", "Raku"}, Score: 0.6, Strategy: "classifier"},
+		},
+		{
+			name:     "binary",
+			filename: "foo.h",
+			content:  "\x00\x01\x02",
+			expected: Detection{Status: Undetected},
+		},
+		{
+			name:     "unknown",
+			filename: "foo.unknown-extension",
+			content:  "foo",
+			expected: Detection{Status: Undetected},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := d.Detect(test.filename, []byte(test.content))
+			assert.Equal(t, test.expected.Language, r.Language)
+			assert.Equal(t, test.expected.Status, r.Status)
+			assert.Equal(t, test.expected.Candidates, r.Candidates)
+			assert.InDelta(t, test.expected.Score, r.Score, 1e-9)
+			assert.Equal(t, test.expected.Strategy, r.Strategy)
+			assert.Equal(t, d.GetLanguage(test.filename, []byte(test.content)), r.Language)
+		})
+	}
+
+	assert.Equal(t, Definitive, Detect("main.go", []byte("package main")).Status)
+}
+
+func TestDetectionCertain(t *testing.T) {
+	tests := []struct {
+		detection Detection
+		expected  string
+	}{
+		{Detection{Language: "Go", Status: Definitive}, "Go"},
+		{Detection{Language: "Objective-C", Status: Heuristic}, "Objective-C"},
+		{Detection{Language: "C", Status: Classified, Score: 0.9}, "C"},
+		{Detection{Language: "C", Status: Classified, Score: 0.5}, OtherLanguage},
+		{Detection{Language: "C", Status: Ambiguous, Score: 0.9}, OtherLanguage},
+		{Detection{Status: Undetected}, OtherLanguage},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.detection.Certain(0.8), test.detection.Status.String())
+	}
+
+	assert.Equal(t, "classified", Classified.String())
+	assert.Equal(t, "unknown", Status(42).String())
+}
+
+func TestDetectorMinScore(t *testing.T) {
+	c := fixedClassifier{"Perl": 0.3, "Prolog": 0.6, "Raku": 0.1}
+	content := []byte("print 1;\n")
+
+	d, err := NewDetector(WithClassifier(c), WithMinScore(0.5))
+	require.NoError(t, err)
+	assert.Equal(t, "Prolog", d.GetLanguage("foo.pl", content))
+	assert.Equal(t, OtherLanguage, d.GetLanguage("foo.pl", nil))
+	assert.Equal(t, "Go", d.GetLanguage("main.go", nil))
+
+	d, err = NewDetector(WithClassifier(c), WithMinScore(0.7))
+	require.NoError(t, err)
+	assert.Equal(t, OtherLanguage, d.GetLanguage("foo.pl", content))
+	assert.Equal(t, []string{"Prolog", "Perl", "Raku"}, d.GetLanguages("foo.pl", content))
+}
+
+func TestDetectorMinScoreShippedModel(t *testing.T) {
+	// .cgi files are Perl, Python or Shell, with no content heuristics to
+	// tell them apart, so the classifier decides unless there is a shebang
+	candidates := []string{"Perl", "Python", "Shell"}
+	if data.ClassifierTemperature == 0 {
+		t.Skip("the classifier model isn't calibrated, see data/calibration.go")
+	}
+	for _, language := range candidates {
+		if _, ok := data.LanguagesLogProbabilities[language]; !ok {
+			t.Skipf("the classifier model doesn't know %s", language)
+		}
+	}
+
+	// a script printing a page, without its shebang, as valid in Python 2
+	// as it almost is in Perl
+	content := []byte("print \"Content-Type: text/html\"\nprint\nprint \"<p>hello</p>\"\n")
+	detection := Detect("index.cgi", content)
+	require.Equal(t, Classified, detection.Status)
+	assert.ElementsMatch(t, candidates, detection.Candidates)
+	assert.True(t, detection.Score < 0.95, "score %v", detection.Score)
+
+	d, err := NewDetector(WithMinScore(0.95))
+	require.NoError(t, err)
+	assert.Equal(t, OtherLanguage, d.GetLanguage("index.cgi", content))
+	assert.Equal(t, detection.Candidates, d.GetLanguages("index.cgi", content))
+}
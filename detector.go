@@ -0,0 +1,248 @@
+package enry
+
+import (
+	"fmt"
+
+	"github.com/go-enry/go-enry/v2/cache"
+	"github.com/go-enry/go-enry/v2/data"
+	"github.com/go-enry/go-enry/v2/ngram"
+	"github.com/go-enry/go-enry/v2/regex"
+	"github.com/go-enry/go-enry/v2/tokenizer"
+)
+
+// Detector applies the same strategies as GetLanguage, but with its own
+// configuration instead of the package-level defaults. It is safe for
+// concurrent use by multiple goroutines.
+type Detector struct {
+	engine     regex.Engine
+	heuristics map[string]*data.Heuristics
+	strategies []Strategy
+	cache      cache.Cache
+	// cacheConfig is the Config of the cache keys, see cacheKey
+	cacheConfig string
+	classifier  classifier
+	// minScore is the minimum score of a classifier guess GetLanguage returns, if guesses are disabled
+	minScore float64
+	noGuess  bool
+}
+
+// Option configures a Detector.
+type Option func(*Detector) error
+
+// WithRegexEngine makes the Detector run content heuristics on the regex
+// engine registered with the given name. See regex.Engines for the ones
+// available in the current build.
+func WithRegexEngine(name string) Option {
+	return func(d *Detector) error {
+		engine, err := regex.Lookup(name)
+		if err != nil {
+			return err
+		}
+
+		d.engine = engine
+		return nil
+	}
+}
+
+// WithTokenizer makes the Detector classifier tokenize only the parts of the
+// content selected by opts, instead of its first tokenizer.ByteLimit bytes.
+func WithTokenizer(opts tokenizer.Options) Option {
+	return func(d *Detector) error {
+		d.classifier = newNaiveBayes(opts)
+		return nil
+	}
+}
+
+// WithNgramModel makes the Detector classifier run the given character n-gram
+// model, see the ngram package, instead of Naive Bayes. It replaces the
+// classifier configured by WithTokenizer.
+func WithNgramModel(m *ngram.Model) Option {
+	return WithClassifier(m)
+}
+
+// WithClassifier makes the Detector sort the candidates left by the other
+// strategies by their probabilities according to c, e.g. an Ensemble,
+// instead of running Naive Bayes. It replaces the classifier configured by
+// WithTokenizer.
+func WithClassifier(c Classifier) Option {
+	return func(d *Detector) error {
+		d.classifier = probabilisticClassifier{c}
+		return nil
+	}
+}
+
+// WithMinScore makes GetLanguage of the Detector return OtherLanguage
+// instead of guessing when the strategies can't tell the language for sure:
+// if the result of Detect is Ambiguous, or Classified with a Score lower
+// than minScore. GetLanguages still returns all the candidates.
+func WithMinScore(minScore float64) Option {
+	return func(d *Detector) error {
+		d.noGuess = true
+		d.minScore = minScore
+		return nil
+	}
+}
+
+// WithCache makes the Detector store the languages it detects in c, and
+// return them without running any strategy when it is given the same file
+// name and content again. See the cache package for the implementations.
+//
+// The keys include the regex engine, the tokenizer options and the type of
+// the classifier of the Detector, so that Detectors configured differently
+// can share a cache, except with classifiers of the same type trained
+// differently, e.g. two n-gram models, or with samplers other than the ones
+// of the tokenizer package, which are only told apart by their function. Only GetLanguages, GetLanguagesByHash
+// and the batch detection use the cache: Detect, and GetLanguage with
+// WithMinScore, which report the scores of the classifier, always run the
+// strategies.
+func WithCache(c cache.Cache) Option {
+	return func(d *Detector) error {
+		d.cache = c
+		return nil
+	}
+}
+
+// NewDetector returns a Detector configured by the given options.
+// Without options it behaves exactly as GetLanguage.
+func NewDetector(opts ...Option) (*Detector, error) {
+	d := &Detector{
+		engine:     regex.Default(),
+		heuristics: data.ContentHeuristics,
+		classifier: defaultClassifier,
+	}
+
+	for _, opt := range opts {
+		if err := opt(d); err != nil {
+			return nil, err
+		}
+	}
+
+	if d.engine.Name() != regex.Name {
+		heuristics, err := data.CompileContentHeuristics(d.engine)
+		if err != nil {
+			return nil, err
+		}
+		d.heuristics = heuristics
+	}
+
+	d.strategies = make([]Strategy, 0, len(definitiveStrategies)+2)
+	for _, s := range definitiveStrategies {
+		d.strategies = append(d.strategies, s.strategy)
+	}
+	d.strategies = append(d.strategies, d.GetLanguagesByContent, d.GetLanguagesByClassifier)
+
+	if d.cache != nil {
+		d.cacheConfig = d.engine.Name() + " " + classifierConfig(d.classifier)
+	}
+
+	return d, nil
+}
+
+// classifierConfig describes a classifier for the cache keys.
+func classifierConfig(c classifier) string {
+	switch c := c.(type) {
+	case *naiveBayes:
+		return "naive-bayes " + tokenizerConfig(c.tokenizer)
+	case probabilisticClassifier:
+		return fmt.Sprintf("%T", c.Classifier)
+	}
+	return fmt.Sprintf("%T", c)
+}
+
+// tokenizerConfig describes tokenizer options for the cache keys, by the
+// parameters of their Sampler, e.g. windows(4), which the samplers of the
+// tokenizer package print.
+func tokenizerConfig(opts tokenizer.Options) string {
+	sampler := opts.Sampler
+	if sampler == nil {
+		sampler = tokenizer.Head
+	}
+
+	return fmt.Sprintf("limit=%d skip-leading-comments=%t sampler=%v", opts.Limit, opts.SkipLeadingComments, sampler)
+}
+
+// cacheKey returns the key of a file in the cache of the Detector.
+func (d *Detector) cacheKey(key cache.Key) cache.Key {
+	key.Config = d.cacheConfig
+	return key
+}
+
+// RegexEngine returns the name of the regex engine used by the content heuristics.
+func (d *Detector) RegexEngine() string {
+	return d.engine.Name()
+}
+
+// GetLanguage is the same as the package-level GetLanguage, using the Detector configuration.
+// With WithMinScore, it returns OtherLanguage instead of guessing, see Detection.Certain.
+func (d *Detector) GetLanguage(filename string, content []byte) (language string) {
+	if d.noGuess {
+		return d.Detect(filename, content).Certain(d.minScore)
+	}
+
+	languages := d.GetLanguages(filename, content)
+	return firstLanguage(languages)
+}
+
+// GetLanguages is the same as the package-level GetLanguages, using the Detector configuration.
+func (d *Detector) GetLanguages(filename string, content []byte) []string {
+	if d.cache == nil {
+		return getLanguagesByStrategies(d.strategies, filename, content)
+	}
+
+	return d.getLanguagesCached(d.cacheKey(cache.KeyOf(filename, content)), filename, content)
+}
+
+// GetLanguagesByHash is the same as GetLanguages, for a file whose content
+// is identified by hash, e.g. its git blob SHA. The content is only loaded
+// when the Detector has no cache or the cache misses.
+func (d *Detector) GetLanguagesByHash(filename, hash string, load func() ([]byte, error)) ([]string, error) {
+	if d.cache == nil {
+		content, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return getLanguagesByStrategies(d.strategies, filename, content), nil
+	}
+
+	key := d.cacheKey(cache.NewKey(filename, hash))
+	if languages, ok := d.cache.Get(key); ok {
+		return languages, nil
+	}
+
+	content, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	languages := getLanguagesByStrategies(d.strategies, filename, content)
+	d.cache.Put(key, languages)
+	return languages, nil
+}
+
+func (d *Detector) getLanguagesCached(key cache.Key, filename string, content []byte) []string {
+	if languages, ok := d.cache.Get(key); ok {
+		return languages
+	}
+
+	languages := getLanguagesByStrategies(d.strategies, filename, content)
+	d.cache.Put(key, languages)
+	return languages
+}
+
+// GetLanguagesByContent is the same as the package-level GetLanguagesByContent,
+// using the heuristics compiled for the Detector regex engine.
+// It complies with the signature to be a Strategy type.
+func (d *Detector) GetLanguagesByContent(filename string, content []byte, _ []string) []string {
+	return getLanguagesByHeuristics(d.heuristics, filename, content)
+}
+
+// GetLanguagesByClassifier is the same as the package-level
+// GetLanguagesByClassifier, using the Detector tokenizer options.
+// It complies with the signature to be a Strategy type.
+func (d *Detector) GetLanguagesByClassifier(filename string, content []byte, candidates []string) []string {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return getLanguagesBySpecificClassifier(content, candidates, d.classifier)
+}
@@ -0,0 +1,179 @@
+package enry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-enry/go-enry/v2/cache"
+	"github.com/go-enry/go-enry/v2/ngram"
+	"github.com/go-enry/go-enry/v2/regex"
+	"github.com/go-enry/go-enry/v2/tokenizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector()
+	require.NoError(t, err)
+	assert.Equal(t, regex.Name, d.RegexEngine())
+
+	_, err = NewDetector(WithRegexEngine("no-such-engine"))
+	assert.Error(t, err)
+}
+
+func TestDetectorRegexEngines(t *testing.T) {
+	tests := []struct {
+		filename string
+		content  string
+		expected string
+	}{
+		{filename: "foo.m", content: ":- module", expected: "Mercury"},
+		{filename: "foo.ms", content: "\t.include \"math.s\"", expected: "Unix Assembly"},
+		{filename: "foo.go", content: "package main", expected: "Go"},
+	}
+
+	for _, engine := range regex.Engines() {
+		d, err := NewDetector(WithRegexEngine(engine))
+		require.NoError(t, err)
+		assert.Equal(t, engine, d.RegexEngine())
+
+		for _, test := range tests {
+			t.Run(engine+"/"+test.filename, func(t *testing.T) {
+				content := []byte(test.content)
+				assert.Equal(t, test.expected, d.GetLanguage(test.filename, content))
+				assert.Equal(t, GetLanguage(test.filename, content), d.GetLanguage(test.filename, content))
+			})
+		}
+	}
+}
+
+func TestDetectorTokenizer(t *testing.T) {
+	head := strings.Repeat("foo\n", 10)
+	content := []byte(head + strings.Repeat("bar\n", 20))
+	candidates := []string{"Foo", "Bar"}
+
+	tests := []struct {
+		name     string
+		opts     tokenizer.Options
+		expected string
+	}{
+		{name: "default", opts: tokenizer.Options{}, expected: "Bar"},
+		{name: "head", opts: tokenizer.Options{Limit: len(head)}, expected: "Foo"},
+		{name: "head-tail", opts: tokenizer.Options{Limit: len(head), Sampler: tokenizer.HeadTail}, expected: "Bar"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := NewDetector(WithTokenizer(test.opts))
+			require.NoError(t, err)
+
+			nb := d.classifier.(*naiveBayes)
+			nb.languagesLogProbabilities = map[string]float64{"Foo": -0.1, "Bar": -0.1}
+			nb.tokensLogProbabilities = map[string]map[string]float64{
+				"Foo": {"foo": -0.1, "bar": -5},
+				"Bar": {"foo": -2, "bar": -0.1},
+			}
+			nb.tokensTotal = 10
+
+			languages := d.GetLanguagesByClassifier("foo", content, candidates)
+			assert.Equal(t, test.expected, languages[0])
+		})
+	}
+}
+
+func TestDetectorNgramModel(t *testing.T) {
+	var samples []ngram.Sample
+	for i := 0; i < 10; i++ {
+		samples = append(samples,
+			ngram.Sample{Language: "C", Content: []byte(fmt.Sprintf("printf(\"%d\");\nstruct s%d;", i, i))},
+			ngram.Sample{Language: "C++", Content: []byte(fmt.Sprintf("std::cout << %d;\nclass c%d;", i, i))},
+		)
+	}
+
+	m, err := ngram.Train(samples, ngram.TrainOptions{Buckets: 1 << 10})
+	require.NoError(t, err)
+
+	d, err := NewDetector(WithNgramModel(m))
+	require.NoError(t, err)
+
+	candidates := []string{"c", "C++", "Objective-C"}
+	assert.Equal(t, []string{"C++", "C", "Objective-C"}, d.GetLanguagesByClassifier("foo.h", []byte("std::cout << x;"), candidates))
+	assert.Equal(t, []string{"C", "C++", "Objective-C"}, d.GetLanguagesByClassifier("foo.h", []byte("printf(x);"), candidates))
+}
+
+func TestDetectorCache(t *testing.T) {
+	c := cache.NewLRU(10)
+	d, err := NewDetector(WithCache(c))
+	require.NoError(t, err)
+
+	content := []byte("package main")
+	assert.Equal(t, "Go", d.GetLanguage("main.go", content))
+	languages, ok := c.Get(d.cacheKey(cache.KeyOf("main.go", content)))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Go"}, languages)
+
+	// cached languages are returned without running any strategy
+	c.Put(d.cacheKey(cache.KeyOf("cached.go", content)), []string{"Cached"})
+	assert.Equal(t, "Cached", d.GetLanguage("cached.go", content))
+	assert.Equal(t, "Go", d.GetLanguage("cached.go", []byte("package other")))
+
+	var loads int
+	load := func() ([]byte, error) {
+		loads++
+		return content, nil
+	}
+	languages, err = d.GetLanguagesByHash("dir/main.go", "blob", load)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Go"}, languages)
+	languages, err = d.GetLanguagesByHash("other/main.go", "blob", load)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Go"}, languages)
+	assert.Equal(t, 1, loads)
+
+	_, err = d.GetLanguagesByHash("foo.go", "missing", func() ([]byte, error) {
+		return nil, errors.New("missing")
+	})
+	assert.EqualError(t, err, "missing")
+}
+
+func TestDetectorCacheConfig(t *testing.T) {
+	c := cache.NewLRU(10)
+	configs := map[string]bool{}
+	for _, opts := range [][]Option{
+		nil,
+		{WithTokenizer(tokenizer.Options{Limit: 100})},
+		{WithTokenizer(tokenizer.Options{Sampler: tokenizer.HeadTail})},
+		{WithTokenizer(tokenizer.Options{Sampler: tokenizer.Windows(2)})},
+		{WithTokenizer(tokenizer.Options{Sampler: tokenizer.Windows(3)})},
+		{WithTokenizer(tokenizer.Options{Sampler: tokenizer.Windows(4)})},
+		{WithTokenizer(tokenizer.Options{SkipLeadingComments: true})},
+		{WithTokenizer(tokenizer.Options{Limit: -1})},
+		{WithNgramModel(&ngram.Model{})},
+	} {
+		d, err := NewDetector(append(opts, WithCache(c))...)
+		require.NoError(t, err)
+		assert.False(t, configs[d.cacheConfig], d.cacheConfig)
+		configs[d.cacheConfig] = true
+	}
+
+	// the default sampler is Head, and so is a single window
+	d, err := NewDetector(WithTokenizer(tokenizer.Options{Sampler: tokenizer.Windows(1)}), WithCache(c))
+	require.NoError(t, err)
+	assert.True(t, configs[d.cacheConfig])
+	assert.Contains(t, d.cacheConfig, "sampler=head")
+
+	d, err = NewDetector(WithCache(c))
+	require.NoError(t, err)
+	assert.True(t, configs[d.cacheConfig])
+
+	// the languages cached by a Detector aren't returned to another one
+	// configured differently
+	content := []byte("package main")
+	c.Put(d.cacheKey(cache.KeyOf("main.go", content)), []string{"Cached"})
+	assert.Equal(t, "Cached", d.GetLanguage("main.go", content))
+	other, err := NewDetector(WithTokenizer(tokenizer.Options{Limit: 100}), WithCache(c))
+	require.NoError(t, err)
+	assert.Equal(t, "Go", other.GetLanguage("main.go", content))
+}
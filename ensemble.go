@@ -0,0 +1,85 @@
+package enry
+
+// Vote is the way an Ensemble combines the probabilities of its members.
+type Vote int
+
+const (
+	// WeightedAverage averages the probabilities of the members, weighted by
+	// their weights. It works best with calibrated probabilities.
+	WeightedAverage Vote = iota
+	// MajorityVote gives every member a vote, as heavy as its weight, for
+	// its most probable candidate. Ties are broken by the weighted average.
+	MajorityVote
+)
+
+// tieBreak is the part of the probabilities of a MajorityVote coming from
+// the weighted average, small enough never to change the outcome of a vote.
+const tieBreak = 1e-3
+
+// Member is a Classifier of an Ensemble.
+type Member struct {
+	Classifier Classifier
+	// Weight is the importance of the Classifier in the Ensemble.
+	// Members with a weight of zero or less are ignored.
+	Weight float64
+}
+
+// Ensemble is a Classifier combining the probabilities of several others,
+// e.g. the built-in Naive Bayes and some n-gram models. Members with no
+// known candidate, whose probabilities are all 0, abstain.
+type Ensemble struct {
+	Vote    Vote
+	Members []Member
+}
+
+// NewEnsemble returns an Ensemble of the given classifiers, all with a weight of 1.
+func NewEnsemble(vote Vote, classifiers ...Classifier) *Ensemble {
+	e := &Ensemble{Vote: vote}
+	for _, c := range classifiers {
+		e.Members = append(e.Members, Member{Classifier: c, Weight: 1})
+	}
+
+	return e
+}
+
+// Probabilities implements Classifier.
+func (e *Ensemble) Probabilities(content []byte, candidates []string) []float64 {
+	average := make([]float64, len(candidates))
+	votes := make([]float64, len(candidates))
+	var total float64
+	for _, m := range e.Members {
+		if m.Weight <= 0 {
+			continue
+		}
+
+		probabilities := m.Classifier.Probabilities(content, candidates)
+		best := -1
+		for i, p := range probabilities {
+			if p > 0 && (best < 0 || p > probabilities[best]) {
+				best = i
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		for i, p := range probabilities {
+			average[i] += m.Weight * p
+		}
+		votes[best] += m.Weight
+		total += m.Weight
+	}
+
+	if total == 0 {
+		return average
+	}
+
+	for i := range average {
+		average[i] /= total
+		if e.Vote == MajorityVote {
+			average[i] = (1-tieBreak)*votes[i]/total + tieBreak*average[i]
+		}
+	}
+
+	return average
+}
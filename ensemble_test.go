@@ -0,0 +1,150 @@
+package enry
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-enry/go-enry/v2/tokenizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClassifier gives the same probabilities to the languages whatever the content.
+type fixedClassifier map[string]float64
+
+func (c fixedClassifier) Probabilities(_ []byte, candidates []string) []float64 {
+	probabilities := make([]float64, len(candidates))
+	var total float64
+	for i, candidate := range candidates {
+		probabilities[i] = c[candidate]
+		total += c[candidate]
+	}
+	for i := range probabilities {
+		if total > 0 {
+			probabilities[i] /= total
+		}
+	}
+	return probabilities
+}
+
+func TestEnsemble(t *testing.T) {
+	confident := fixedClassifier{"C": 0.9, "C++": 0.1}
+	unsure := fixedClassifier{"C": 0.4, "C++": 0.6}
+	other := fixedClassifier{"Go": 1}
+	candidates := []string{"C", "C++", "Objective-C"}
+
+	tests := []struct {
+		name     string
+		ensemble *Ensemble
+		expected []float64
+	}{
+		{
+			name:     "average",
+			ensemble: NewEnsemble(WeightedAverage, confident, unsure),
+			expected: []float64{0.65, 0.35, 0},
+		},
+		{
+			name: "weighted average",
+			ensemble: &Ensemble{Vote: WeightedAverage, Members: []Member{
+				{Classifier: confident, Weight: 1},
+				{Classifier: unsure, Weight: 3},
+			}},
+			expected: []float64{0.525, 0.475, 0},
+		},
+		{
+			name:     "abstain",
+			ensemble: NewEnsemble(WeightedAverage, confident, other),
+			expected: []float64{0.9, 0.1, 0},
+		},
+		{
+			name: "ignored",
+			ensemble: &Ensemble{Vote: WeightedAverage, Members: []Member{
+				{Classifier: confident, Weight: 0},
+				{Classifier: unsure, Weight: 1},
+			}},
+			expected: []float64{0.4, 0.6, 0},
+		},
+		{
+			name:     "nobody",
+			ensemble: NewEnsemble(MajorityVote, other),
+			expected: []float64{0, 0, 0},
+		},
+		{
+			name:     "vote tie",
+			ensemble: NewEnsemble(MajorityVote, confident, unsure),
+			expected: []float64{0.5 + 0.15e-3, 0.5 - 0.15e-3, 0},
+		},
+		{
+			name:     "vote",
+			ensemble: NewEnsemble(MajorityVote, confident, unsure, unsure),
+			expected: []float64{(1-tieBreak)/3 + tieBreak*1.7/3, (1-tieBreak)*2/3 + tieBreak*1.3/3, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			probabilities := test.ensemble.Probabilities(nil, candidates)
+			assert.InDeltaSlice(t, test.expected, probabilities, 1e-9)
+		})
+	}
+}
+
+func TestClassifierStrategy(t *testing.T) {
+	strategy := ClassifierStrategy(NewEnsemble(MajorityVote,
+		fixedClassifier{"C": 0.9, "C++": 0.1},
+		fixedClassifier{"C": 0.4, "C++": 0.6},
+		fixedClassifier{"C": 0.1, "C++": 0.9},
+	))
+
+	assert.Nil(t, strategy("foo.h", nil, nil))
+	assert.Equal(t, []string{"C++", "C", "Objective-C"}, strategy("foo.h", nil, []string{"C", "c++", "Objective-C"}))
+
+	d, err := NewDetector(WithClassifier(fixedClassifier{"C": 1}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C", "C++", "Objective-C"}, d.GetLanguagesByClassifier("foo.h", nil, []string{"Objective-C", "C++", "C"}))
+}
+
+func TestNaiveBayesProbabilities(t *testing.T) {
+	nb := NewNaiveBayes(tokenizer.Options{})
+	content := []byte("public class Foo {\n\tpublic static void main(String[] args) {}\n}\n")
+	candidates := []string{"C", "Java", "JavaScript", "Unknown"}
+
+	probabilities := nb.Probabilities(content, candidates)
+	assert.InDelta(t, 1, probabilities[0]+probabilities[1]+probabilities[2], 1e-9)
+	assert.Equal(t, 0.0, probabilities[3])
+
+	best := 0
+	for i, p := range probabilities {
+		if p > probabilities[best] {
+			best = i
+		}
+	}
+	assert.Equal(t, GetLanguagesByClassifier("", content, candidates)[0], candidates[best])
+}
+
+func TestNaiveBayesUnknownCandidates(t *testing.T) {
+	m := NaiveBayesModel{
+		LanguagesLogProbabilities: map[string]float64{"Foo": math.Log(0.5), "Bar": math.Log(0.5)},
+		TokensLogProbabilities: map[string]map[string]float64{
+			"Foo": {"foo": -0.1, "bar": -3},
+			"Bar": {"foo": -3, "bar": -0.1},
+		},
+		TokensTotal: 10,
+	}
+	nb := NewNaiveBayesWithModel(m, tokenizer.Options{})
+
+	// the tokens unknown to the model score the same for every language, so
+	// that the lack of a prior alone would rank Unknown above Bar
+	content := []byte("foo\n" + strings.Repeat("baz\n", 10))
+	candidates := []string{"Unknown", "Bar", "Foo"}
+	expected := []string{"Foo", "Bar", "Unknown"}
+
+	probabilities := nb.Probabilities(content, candidates)
+	assert.Equal(t, 0.0, probabilities[0])
+	assert.True(t, probabilities[2] > probabilities[1])
+
+	// GetLanguage ranks the candidates by score, Detect by probability
+	assert.Equal(t, expected, getLanguagesBySpecificClassifier(content, candidates, nb.(*naiveBayes)))
+	assert.Equal(t, expected, sortLanguagesByScore(rankCandidates(nb, content, candidates)))
+}
@@ -3,6 +3,7 @@ package generator
 import (
 	"bytes"
 	"io/ioutil"
+	"text/template"
 
 	"gopkg.in/yaml.v2"
 )
@@ -21,7 +22,8 @@ func Documentation(fileToParse, _, outFile, tmplPath, tmplName, commit string) e
 	}
 
 	buf := &bytes.Buffer{}
-	err = executeTemplate(buf, tmplName, tmplPath, commit, nil, regexpList)
+	funcs := template.FuncMap{"requiredLiteral": requiredLiteral}
+	err = executeTemplate(buf, tmplName, tmplPath, commit, funcs, regexpList)
 	if err != nil {
 		return err
 	}
@@ -17,7 +17,9 @@ import (
 	"github.com/go-enry/go-enry/v2/internal/tokenizer"
 )
 
-type samplesFrequencies struct {
+// SamplesFrequencies are the frequencies of languages and tokens in Linguist
+// samples, which the Naive Bayes classifier is trained on.
+type SamplesFrequencies struct {
 	LanguageTotal  int                       `json:"language_total,omitempty"`
 	Languages      map[string]int            `json:"languages,omitempty"`
 	TokensTotal    int                       `json:"tokens_total,omitempty"`
@@ -56,18 +58,68 @@ func Frequencies(fileToParse, samplesDir, outPath, tmplPath, tmplName, commit st
 	return formatedWrite(outPath, buf.Bytes())
 }
 
-func getFrequencies(samplesDir string) (*samplesFrequencies, error) {
-	langDirs, err := ioutil.ReadDir(samplesDir)
+func getFrequencies(samplesDir string) (*SamplesFrequencies, error) {
+	langSamples, err := LanguageSamples(samplesDir)
 	if err != nil {
 		return nil, err
 	}
 
-	var languageTotal int
-	var languages = make(map[string]int)
-	var tokensTotal int
-	var tokens = make(map[string]map[string]int)
-	var languageTokens = make(map[string]int)
+	freqs := NewSamplesFrequencies()
+	for lang, samples := range langSamples {
+		samplesTokens, err := getTokens(samples)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		freqs.AddLanguage(lang, len(samples), samplesTokens)
+	}
+
+	return freqs, nil
+}
+
+// NewSamplesFrequencies returns empty SamplesFrequencies.
+func NewSamplesFrequencies() *SamplesFrequencies {
+	return &SamplesFrequencies{
+		Languages:      make(map[string]int),
+		Tokens:         make(map[string]map[string]int),
+		LanguageTokens: make(map[string]int),
+	}
+}
+
+// AddLanguage adds the given number of samples of a language, and all
+// their tokens. Every language must be added only once.
+func (f *SamplesFrequencies) AddLanguage(lang string, samples int, samplesTokens []string) {
+	f.LanguageTotal += samples
+	f.Languages[lang] = samples
+	f.TokensTotal += len(samplesTokens)
+	f.LanguageTokens[lang] = len(samplesTokens)
+	f.Tokens[lang] = make(map[string]int)
+	for _, token := range samplesTokens {
+		f.Tokens[lang][token]++
+	}
+}
+
+// LanguageLogProbability returns the log probability of a sample being of the language.
+func (f *SamplesFrequencies) LanguageLogProbability(lang string) float64 {
+	return math.Log(float64(f.Languages[lang]) / float64(f.LanguageTotal))
+}
+
+// TokenLogProbability returns the log probability of a token of the language being token.
+func (f *SamplesFrequencies) TokenLogProbability(lang, token string) float64 {
+	return math.Log(float64(f.Tokens[lang][token]) / float64(f.LanguageTokens[lang]))
+}
+
+// LanguageSamples returns the paths of the samples of every language in
+// samplesDir, a directory per language as in Linguist ./samples/.
+// Languages without samples are left out.
+func LanguageSamples(samplesDir string) (map[string][]string, error) {
+	langDirs, err := ioutil.ReadDir(samplesDir)
+	if err != nil {
+		return nil, err
+	}
 
+	langSamples := make(map[string][]string)
 	for _, langDir := range langDirs {
 		if !langDir.IsDir() {
 			continue
@@ -83,29 +135,10 @@ func getFrequencies(samplesDir string) (*samplesFrequencies, error) {
 			continue
 		}
 
-		samplesTokens, err := getTokens(samples)
-		if err != nil {
-			log.Println(err)
-			continue
-		}
-
-		languageTotal += len(samples)
-		languages[lang] = len(samples)
-		tokensTotal += len(samplesTokens)
-		languageTokens[lang] = len(samplesTokens)
-		tokens[lang] = make(map[string]int)
-		for _, token := range samplesTokens {
-			tokens[lang][token]++
-		}
+		langSamples[lang] = samples
 	}
 
-	return &samplesFrequencies{
-		TokensTotal:    tokensTotal,
-		LanguageTotal:  languageTotal,
-		Tokens:         tokens,
-		LanguageTokens: languageTokens,
-		Languages:      languages,
-	}, nil
+	return langSamples, nil
 }
 
 // readSamples collects ./samples/ filenames from the Linguist codebase, skipping symlinks.
@@ -167,7 +200,7 @@ func getTokens(samples []string) ([]string, error) {
 	return tokens, anyError
 }
 
-func executeFrequenciesTemplate(out io.Writer, freqs *samplesFrequencies, tmplPath, tmplName, commit string) error {
+func executeFrequenciesTemplate(out io.Writer, freqs *SamplesFrequencies, tmplPath, tmplName, commit string) error {
 	fmap := template.FuncMap{
 		"toFloat64": func(num int) string { return fmt.Sprintf("%f", float64(num)) },
 		"orderKeys": func(m map[string]int) []string {
@@ -180,8 +213,7 @@ func executeFrequenciesTemplate(out io.Writer, freqs *samplesFrequencies, tmplPa
 			return keys
 		},
 		"languageLogProbability": func(language string) string {
-			num := math.Log(float64(freqs.Languages[language]) / float64(freqs.LanguageTotal))
-			return fmt.Sprintf("%f", num)
+			return fmt.Sprintf("%f", freqs.LanguageLogProbability(language))
 		},
 		"orderMapMapKeys": func(mm map[string]map[string]int) []string {
 			keys := make([]string, 0, len(mm))
@@ -193,8 +225,7 @@ func executeFrequenciesTemplate(out io.Writer, freqs *samplesFrequencies, tmplPa
 			return keys
 		},
 		"tokenLogProbability": func(language, token string) string {
-			num := math.Log(float64(freqs.Tokens[language][token]) / float64(freqs.LanguageTokens[language]))
-			return fmt.Sprintf("%f", num)
+			return fmt.Sprintf("%f", freqs.TokenLogProbability(language, token))
 		},
 		"quote": strconv.Quote,
 	}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"regexp/syntax"
+)
+
+// requiredLiteral returns the longest literal string that every match of the
+// regexp has to contain, or "" if there is none or regexp is not RE2.
+// It is used as a cheap prefilter, to skip running the regexp at all on
+// strings that do not contain the literal.
+func requiredLiteral(regexp string) string {
+	if !isRE2(regexp) {
+		return ""
+	}
+
+	re, err := syntax.Parse(regexp, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+
+	return longestLiteral(re.Simplify())
+}
+
+func longestLiteral(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return ""
+		}
+		return string(re.Rune)
+	case syntax.OpCapture, syntax.OpPlus:
+		return longestLiteral(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return ""
+		}
+		return longestLiteral(re.Sub[0])
+	case syntax.OpConcat:
+		// adjacent literals are matched one after another, so they
+		// can be joined to make a longer one
+		var longest, run string
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral && sub.Flags&syntax.FoldCase == 0 {
+				run += string(sub.Rune)
+			} else {
+				run = ""
+			}
+			longest = longer(longest, run)
+			longest = longer(longest, longestLiteral(sub))
+		}
+		return longest
+	}
+
+	// alternations, optional parts, classes and anchors
+	// do not have a literal common to all their matches
+	return ""
+}
+
+func longer(a, b string) string {
+	if len(b) > len(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredLiteral(t *testing.T) {
+	tests := []struct {
+		re       string
+		expected string
+		matching []string
+	}{
+		{re: `(^|/)node_modules/`, expected: "node_modules/", matching: []string{"a/node_modules/b"}},
+		{re: `^[Dd]ocs?/`, expected: "oc", matching: []string{"Doc/", "docs/"}},
+		{re: `(3rd|[Tt]hird)[-_]?[Pp]arty/`, expected: "arty/", matching: []string{"3rd-party/"}},
+		{re: `(.*?)\.d\.ts$`, expected: ".d.ts", matching: []string{"foo.d.ts"}},
+		{re: `(^|/)cordova\-\d\.\d(\.\d)?\.js$`, expected: "cordova-", matching: []string{"cordova-2.7.js"}},
+		{re: `(^|/)[Vv]+endor/`, expected: "endor/", matching: []string{"VVendor/"}},
+		{re: `(?i)\.designer\.(cs|vb)$`, expected: "", matching: []string{"A.Designer.cs"}},
+		{re: `(^|/)(foo|bar)/`, expected: "/", matching: []string{"x/foo/"}},
+		{re: `(a|b)?`, expected: "", matching: []string{""}},
+		{re: `(?<!x)y`, expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.re, func(t *testing.T) {
+			literal := requiredLiteral(test.re)
+			assert.Equal(t, test.expected, literal)
+			for _, s := range test.matching {
+				assert.True(t, regexp.MustCompile(test.re).MatchString(s))
+				assert.True(t, strings.Contains(s, literal), "%q must contain %q", s, literal)
+			}
+		})
+	}
+}
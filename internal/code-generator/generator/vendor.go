@@ -41,7 +41,10 @@ func Vendor(fileToParse, samplesDir, outPath, tmplPath, tmplName, commit string)
 }
 
 func executeVendorTemplate(out io.Writer, regexps []string, tmplPath, tmplName, commit string) error {
-	funcs := template.FuncMap{"collateAllRegexps": collateAllRegexps}
+	funcs := template.FuncMap{
+		"collateAllRegexps": collateAllRegexps,
+		"requiredLiteral":   requiredLiteral,
+	}
 	return executeTemplate(out, tmplName, tmplPath, commit, funcs, regexps)
 }
 
@@ -66,6 +69,8 @@ func collateAllRegexps(regexps []string) string {
 		caretOrSlash = "(^|/)"
 	)
 
+	// sort a copy, the order of regexps is kept for the other matchers
+	regexps = append([]string(nil), regexps...)
 	sort.Strings(regexps)
 
 	// Check prefix, group expressions
@@ -0,0 +1,163 @@
+// Package crossval evaluates the classifiers of enry by k-fold
+// cross-validation on Linguist samples: they are trained on all the folds of
+// the samples but one, and tested on the fold left out. It is shared by
+// cmd/enry-eval and cmd/enry-ngram.
+package crossval
+
+import (
+	"io/ioutil"
+	"log"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/go-enry/go-enry/v2/data"
+	"github.com/go-enry/go-enry/v2/internal/code-generator/generator"
+	"github.com/go-enry/go-enry/v2/internal/tokenizer"
+)
+
+// Sample is a Linguist sample, read and tokenized once for all the folds.
+type Sample struct {
+	// Dir is the samples directory it is in, which frequencies are keyed by.
+	Dir      string
+	Language string
+	Path     string
+	Content  []byte
+	Tokens   []string
+	Fold     int
+}
+
+// ReadSamples reads and tokenizes the samples under dir, sorted by language
+// and path.
+func ReadSamples(dir string) ([]*Sample, error) {
+	langSamples, err := generator.LanguageSamples(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(langSamples))
+	for langDir := range langSamples {
+		dirs = append(dirs, langDir)
+	}
+	sort.Strings(dirs)
+
+	var samples []*Sample
+	for _, langDir := range dirs {
+		language, ok := data.LanguageByAlias(langDir)
+		if !ok {
+			language = langDir
+		}
+
+		paths := langSamples[langDir]
+		sort.Strings(paths)
+		for _, path := range paths {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			samples = append(samples, &Sample{
+				Dir:      langDir,
+				Language: language,
+				Path:     path,
+				Content:  content,
+				Tokens:   tokenizer.Tokenize(content),
+			})
+		}
+	}
+
+	return samples, nil
+}
+
+// AssignFolds spreads the samples of every language evenly over k folds,
+// so that every fold has about the same share of every language.
+func AssignFolds(samples []*Sample, k int) {
+	seen := make(map[string]int)
+	for _, s := range samples {
+		s.Fold = seen[s.Dir] % k
+		seen[s.Dir]++
+	}
+}
+
+// Run assigns the samples to k folds and calls fn with every fold, the
+// samples out of it to train on, and the indexes of the samples in it to test.
+// It stops at the first error of fn.
+func Run(samples []*Sample, k int, fn func(fold int, train []*Sample, test []int) error) error {
+	AssignFolds(samples, k)
+
+	for fold := 0; fold < k; fold++ {
+		var train []*Sample
+		var test []int
+		for i, s := range samples {
+			if s.Fold == fold {
+				test = append(test, i)
+			} else {
+				train = append(train, s)
+			}
+		}
+
+		if err := fn(fold, train, test); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TrainNaiveBayes returns a Naive Bayes model of the samples, counting their
+// frequencies as the code generator does.
+func TrainNaiveBayes(samples []*Sample) enry.NaiveBayesModel {
+	var dirs []string
+	counts := make(map[string]int)
+	tokens := make(map[string][]string)
+	for _, s := range samples {
+		if counts[s.Dir] == 0 {
+			dirs = append(dirs, s.Dir)
+		}
+		counts[s.Dir]++
+		tokens[s.Dir] = append(tokens[s.Dir], s.Tokens...)
+	}
+
+	freqs := generator.NewSamplesFrequencies()
+	for _, dir := range dirs {
+		freqs.AddLanguage(dir, counts[dir], tokens[dir])
+	}
+
+	m := enry.NaiveBayesModel{
+		LanguagesLogProbabilities: make(map[string]float64, len(freqs.Languages)),
+		TokensLogProbabilities:    make(map[string]map[string]float64, len(freqs.Tokens)),
+		TokensTotal:               float64(freqs.TokensTotal),
+	}
+	for lang := range freqs.Languages {
+		m.LanguagesLogProbabilities[lang] = freqs.LanguageLogProbability(lang)
+		m.TokensLogProbabilities[lang] = make(map[string]float64, len(freqs.Tokens[lang]))
+		for token := range freqs.Tokens[lang] {
+			m.TokensLogProbabilities[lang][token] = freqs.TokenLogProbability(lang, token)
+		}
+	}
+
+	return m
+}
+
+// Parallel calls fn with every integer in [0, n) on as many goroutines as CPUs.
+func Parallel(n int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
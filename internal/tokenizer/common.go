@@ -1,7 +1,9 @@
 // Package tokenizer implements file tokenization used by the enry content
 // classifier. This package is an implementation detail of enry and should not
-// be imported by other packages.
+// be imported by other packages; see the public tokenizer package instead.
 package tokenizer
 
+import "github.com/go-enry/go-enry/v2/tokenizer"
+
 // ByteLimit defines the maximum prefix of an input text that will be tokenized.
-const ByteLimit = 100000
+const ByteLimit = tokenizer.ByteLimit
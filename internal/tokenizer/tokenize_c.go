@@ -2,7 +2,10 @@
 
 package tokenizer
 
-import "github.com/go-enry/go-enry/v2/internal/tokenizer/flex"
+import (
+	"github.com/go-enry/go-enry/v2/internal/tokenizer/flex"
+	"github.com/go-enry/go-enry/v2/tokenizer"
+)
 
 // Tokenize returns lexical tokens from content. The tokens returned match what
 // the Linguist library returns. At most the first ByteLimit bytes of content are tokenized.
@@ -13,3 +16,13 @@ func Tokenize(content []byte) []string {
 
 	return flex.TokenizeFlex(content)
 }
+
+// TokenizeFunc calls fn with each of the tokens of the parts of content
+// selected by opts, in order. Every part is tokenized on its own.
+func TokenizeFunc(content []byte, opts tokenizer.Options, fn func(token []byte)) {
+	for _, r := range opts.AppendRanges(nil, content) {
+		for _, token := range flex.TokenizeFlex(content[r.Start:r.End]) {
+			fn([]byte(token))
+		}
+	}
+}
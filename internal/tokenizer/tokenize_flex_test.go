@@ -0,0 +1,73 @@
+//go:build flex
+// +build flex
+
+package tokenizer
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-enry/go-enry/v2/internal/tokenizer/flex"
+	"github.com/go-enry/go-enry/v2/tokenizer"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTokenizeFlexParity proves that the pure-Go lexer of the tokenizer
+// package tokenizes exactly as Linguist's flex lexer, over the test corpus
+// and the Linguist samples when ENRY_TEST_REPO points to a clone of Linguist.
+func TestTokenizeFlexParity(t *testing.T) {
+	dirs := []string{"../../_testdata"}
+	if repo := os.Getenv("ENRY_TEST_REPO"); repo != "" {
+		dirs = append(dirs, filepath.Join(repo, "samples"))
+	}
+
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || !info.Mode().IsRegular() {
+				return err
+			}
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			requireFlexParity(t, content, path)
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	requireFlexParity(t, []byte(testContent), "testContent")
+}
+
+// TestTokenizeFlexParityRandom compares both lexers on random content made of
+// the characters the lexer rules care about.
+func TestTokenizeFlexParityRandom(t *testing.T) {
+	const alphabet = "\x00\t\n #!/*{}()[];<>=\"'\\-+%&|.@_?xenvEl0123456789aAfFuU\xe5"
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 20000; i++ {
+		content := make([]byte, r.Intn(64))
+		for j := range content {
+			content[j] = alphabet[r.Intn(len(alphabet))]
+		}
+
+		requireFlexParity(t, content, "")
+	}
+}
+
+func requireFlexParity(t *testing.T, content []byte, name string) {
+	expected := flex.TokenizeFlex(truncate(content))
+	tokens := tokenizer.Tokenize(content)
+	require.Equal(t, expected, tokenizer.Strings(tokens), "%s %q", name, content)
+}
+
+func truncate(content []byte) []byte {
+	if len(content) > ByteLimit {
+		return content[:ByteLimit]
+	}
+	return content
+}
@@ -79,19 +79,16 @@ varBool = 3<=2>
 )
 
 var (
-	tokensFromTestContent = []string{"SHEBANG#!ruby", "SHEBANG#!node", "SHEBANG#!awk", "<!DOCTYPE>", "html", "PUBLIC",
-		"W3C", "DTD", "XHTML", "1", "0", "Strict", "EN", "http", "www", "w3", "org", "TR", "xhtml1", "DTD", "xhtml1",
-		"strict", "dtd", "<html>", "xmlns=", "<head>", "<title>", "id=", "class=", "</title>", "<style>", "type=",
-		"<![CDATA[>", "example", "background", "color", "yellow", "</style>", "</head>", "<body>", "<div>", "id=",
-		"<strong>", "</strong>", "</div>", "</body>", "</html>", "(", "[", "]", ")", "[", "]", "{", "(", ")", "(", ")",
-		"{", "}", "(", ")", ";", "#", "/usr/bin/ruby", "#", "/usr/bin/env", "node", "aaa", "#", "/usr/bin/env", "A",
-		"B", "foo", "bar", "awk", "f", "#", "python", "func", "Tokenize", "content", "byte", "string", "splitted",
-		"bytes.Fields", "content", "tokens", "othercode", "ppp", "no", "comment", "abb", "tokenByte",
-		"notcatchasanumber", "number", "*", "anotherNumber", "if", "isTrue", "isToo", "b", "return", "tokens",
-		"oneBool", "varBool", "#ifndef", "#i", "m", "not", "a", "comment", "if", "the", "single", "line", "comment",
-		"symbol", "is", "not", "followed", "by", "a", "white", "PyErr_SetString", "PyExc_RuntimeError", "This", "is",
-		"a", "XHTML", "sample", "file", "Just", "a", "simple", "XHTML", "test", "page.", "-", "|", "+", "&&", "<", "<",
-		"!", "!", "!", "=", "=", "!", ":", "=", ":", "=", ",", ",", "=", ">", ">", "=", "=", "=", "=", ">", "'", ","}
+	tokensFromTestContent = []string{"SHEBANG#!ruby", "SHEBANG#!node", "aaa", "SHEBANG#!awk", "SHEBANG#!#!python",
+		"func", "Tokenize", "(", "content", "[", "]", "byte", ")", "[", "]", "string", "{", "splitted", "bytes.Fields",
+		"(", "content", ")", "tokens", "othercode", "ppp", "no", "comment", "#", "comment", "abb", "(", "tokenByte",
+		")", "|", "notcatchasanumber3.5", "+", "number", "*", "anotherNumber", "if", "isTrue", "&&", "isToo", "{",
+		"0b00001000", "}", "return", "tokens", "oneBool", "<", "varBool", "<", "#ifndef", "#i", "PyErr_SetString",
+		"(", "PyExc_RuntimeError", ")", ";", "<!DOCTYPE>", "html", "PUBLIC", "W3C", "DTD", "XHTML", "1", "0", "Strict",
+		"EN", "http", "www", "w3", "org", "TR", "xhtml1", "DTD", "xhtml1", "strict", "dtd", "<html>", "xmlns=",
+		"<head>", "<title>", "id=", "class=", "This", "is", "a", "XHTML", "sample", "file", "</title>", "<style>",
+		"type=", "<!>", "CDATA", "example", "background", "color", "yellow", "</style>", "</head>", "<body>", "<div>",
+		"id=", "Just", "a", "simple", "<strong>", "XHTML", "</strong>", "test", "page.", "</div>", "</body>", "</html>"}
 
 	tests = []struct {
 		name     string
@@ -125,7 +122,7 @@ func TestTokenizerLatin1AsUtf8(t *testing.T) {
 	for i, token := range tokens {
 		t.Logf("token %d, %s", i+1, token)
 	}
-	require.Equal(t, 3, len(tokens))
+	require.Equal(t, 2, len(tokens))
 }
 
 func TestRegexpOnInvalidUtf8(t *testing.T) {
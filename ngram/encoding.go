@@ -0,0 +1,231 @@
+package ngram
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// magic starts every serialized Model, followed by the format version.
+const (
+	magic   = "enry-ngram"
+	version = 1
+)
+
+// ErrInvalidModel is returned when reading a Model from something else.
+var ErrInvalidModel = errors.New("ngram: invalid model")
+
+// WriteTo writes m to w in a compact binary format that ReadModel reads back:
+// the weights are quantized to bytes, stored sparsely and compressed.
+// It implements io.WriterTo.
+func (m *Model) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	zw := gzip.NewWriter(cw)
+	bw := bufio.NewWriter(zw)
+	e := &encoder{w: bw}
+
+	e.string(magic)
+	e.uvarint(version)
+	e.uvarint(uint64(m.Order))
+	e.uvarint(uint64(m.Buckets))
+	e.uvarint(uint64(len(m.Languages)))
+	for l, language := range m.Languages {
+		e.string(language)
+		e.float32(m.bias[l])
+		e.float32(m.scales[l])
+	}
+
+	// weights are stored as runs of zeros followed by a non-zero weight
+	var zeros uint64
+	for _, weight := range m.weights {
+		if weight == 0 {
+			zeros++
+			continue
+		}
+
+		e.uvarint(zeros)
+		e.byte(byte(weight))
+		zeros = 0
+	}
+	e.uvarint(zeros)
+
+	if e.err == nil {
+		e.err = bw.Flush()
+	}
+	if e.err == nil {
+		e.err = zw.Close()
+	}
+
+	return cw.n, e.err
+}
+
+// ReadModel reads a Model written by Model.WriteTo.
+func ReadModel(r io.Reader) (*Model, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidModel
+	}
+	defer zr.Close()
+
+	d := &decoder{r: bufio.NewReader(zr)}
+	if d.string() != magic {
+		return nil, ErrInvalidModel
+	}
+	if v := d.uvarint(); v != version {
+		if d.err != nil {
+			return nil, d.err
+		}
+		return nil, fmt.Errorf("ngram: unsupported model version %d", v)
+	}
+
+	order := int(d.uvarint())
+	buckets := int(d.uvarint())
+	count := d.uvarint()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if order < 1 || buckets < 1 || buckets&(buckets-1) != 0 || count > math.MaxInt32/uint64(buckets) {
+		return nil, ErrInvalidModel
+	}
+
+	languages := make([]string, count)
+	bias := make([]float32, count)
+	scales := make([]float32, count)
+	for l := range languages {
+		languages[l] = d.string()
+		bias[l] = d.float32()
+		scales[l] = d.float32()
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	m := newModel(order, buckets, languages)
+	copy(m.bias, bias)
+	copy(m.scales, scales)
+	for i := 0; ; i++ {
+		i += int(d.uvarint())
+		if d.err != nil {
+			return nil, d.err
+		}
+		if i == len(m.weights) {
+			break
+		}
+		if i > len(m.weights) {
+			return nil, ErrInvalidModel
+		}
+
+		m.weights[i] = int8(d.byte())
+	}
+
+	return m, d.err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// encoder writes the values of a Model, keeping the first error.
+type encoder struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (e *encoder) write(p []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(p)
+	}
+}
+
+func (e *encoder) byte(b byte) {
+	if e.err == nil {
+		e.err = e.w.WriteByte(b)
+	}
+}
+
+func (e *encoder) uvarint(v uint64) {
+	n := binary.PutUvarint(e.buf[:], v)
+	e.write(e.buf[:n])
+}
+
+func (e *encoder) float32(f float32) {
+	binary.LittleEndian.PutUint32(e.buf[:4], math.Float32bits(f))
+	e.write(e.buf[:4])
+}
+
+func (e *encoder) string(s string) {
+	e.uvarint(uint64(len(s)))
+	e.write([]byte(s))
+}
+
+// decoder reads the values of a Model, keeping the first error. Once it
+// fails, it returns zero values.
+type decoder struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (d *decoder) fail(err error) {
+	if d.err != nil {
+		return
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	d.err = err
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	b, err := d.r.ReadByte()
+	d.fail(err)
+	return b
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(d.r)
+	d.fail(err)
+	return v
+}
+
+func (d *decoder) float32() float32 {
+	if d.err != nil {
+		return 0
+	}
+	var buf [4]byte
+	_, err := io.ReadFull(d.r, buf[:])
+	d.fail(err)
+	return math.Float32frombits(binary.LittleEndian.Uint32(buf[:]))
+}
+
+func (d *decoder) string() string {
+	n := d.uvarint()
+	if d.err != nil {
+		return ""
+	}
+	if n > 1<<16 {
+		d.fail(ErrInvalidModel)
+		return ""
+	}
+	buf := make([]byte, n)
+	_, err := io.ReadFull(d.r, buf)
+	d.fail(err)
+	return string(buf)
+}
@@ -0,0 +1,194 @@
+// Package ngram implements a language classifier based on multinomial
+// logistic regression over hashed byte n-grams of the content.
+//
+// It is an alternative to the Naive Bayes classifier on Linguist tokens that
+// enry uses by default, and tells apart close languages, e.g. C and C++, from
+// their spelling rather than from whole tokens. A Model is trained with Train
+// or TrainDir, and is used by a Detector configured with enry.WithNgramModel.
+package ngram
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-enry/go-enry/v2/tokenizer"
+)
+
+// Model is a trained n-gram classifier. It is safe for concurrent use by
+// multiple goroutines.
+type Model struct {
+	// Order is the length of the longest n-grams of the model. All the
+	// n-grams from 1 to Order bytes long are features.
+	Order int
+	// Buckets is the number of features n-grams are hashed to.
+	Buckets int
+	// Languages are the languages the model tells apart.
+	Languages []string
+
+	index map[string]int
+	bias  []float32
+	// weights are quantized per language, the weight of feature b for
+	// language l being scales[l] * weights[b*len(Languages)+l].
+	scales  []float32
+	weights []int8
+}
+
+// newModel returns a Model without weights.
+func newModel(order, buckets int, languages []string) *Model {
+	m := &Model{
+		Order:     order,
+		Buckets:   buckets,
+		Languages: languages,
+		index:     make(map[string]int, len(languages)),
+		bias:      make([]float32, len(languages)),
+		scales:    make([]float32, len(languages)),
+		weights:   make([]int8, buckets*len(languages)),
+	}
+
+	for i, language := range languages {
+		m.index[language] = i
+	}
+
+	return m
+}
+
+// Classify returns the candidates sorted by decreasing probability of being
+// the language of content. Without candidates all the languages of the model
+// are considered. Candidates unknown to the model come last, in their order.
+func (m *Model) Classify(content []byte, candidates []string) []string {
+	if len(candidates) == 0 {
+		candidates = m.Languages
+	}
+
+	probabilities := m.Probabilities(content, candidates)
+	languages := append([]string(nil), candidates...)
+	sort.Stable(byProbability{languages, probabilities})
+	return languages
+}
+
+// Probabilities returns the probability of each of the candidates being the
+// language of content, assuming it is one of them. Candidates unknown to the
+// model have a probability of 0.
+func (m *Model) Probabilities(content []byte, candidates []string) []float64 {
+	scores := make([]float64, len(candidates))
+	known := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		l, ok := m.index[candidate]
+		if !ok {
+			l = -1
+		}
+		known[i] = l
+	}
+
+	f := extract(content, m.Order, m.Buckets)
+	max := math.Inf(-1)
+	for i, l := range known {
+		if l < 0 {
+			continue
+		}
+
+		var sum float32
+		for j, b := range f.buckets {
+			sum += f.values[j] * float32(m.weights[b*len(m.Languages)+l])
+		}
+		scores[i] = float64(m.bias[l] + m.scales[l]*sum)
+		if scores[i] > max {
+			max = scores[i]
+		}
+	}
+
+	var total float64
+	for i, l := range known {
+		if l < 0 {
+			scores[i] = 0
+			continue
+		}
+		scores[i] = math.Exp(scores[i] - max)
+		total += scores[i]
+	}
+
+	for i := range scores {
+		if total > 0 {
+			scores[i] /= total
+		}
+	}
+
+	return scores
+}
+
+type byProbability struct {
+	languages     []string
+	probabilities []float64
+}
+
+func (b byProbability) Len() int { return len(b.languages) }
+func (b byProbability) Swap(i, j int) {
+	b.languages[i], b.languages[j] = b.languages[j], b.languages[i]
+	b.probabilities[i], b.probabilities[j] = b.probabilities[j], b.probabilities[i]
+}
+func (b byProbability) Less(i, j int) bool { return b.probabilities[j] < b.probabilities[i] }
+
+// features is a sparse feature vector: the buckets of the n-grams of some
+// content, in increasing order, and their values.
+type features struct {
+	buckets []int
+	values  []float32
+}
+
+const (
+	fnvOffset = 2166136261
+	fnvPrime  = 16777619
+)
+
+// extract returns the features of the first tokenizer.ByteLimit bytes of
+// content: the log-scaled counts of its n-grams hashed to buckets, with an
+// L2 norm of 1.
+func extract(content []byte, order, buckets int) features {
+	if len(content) > tokenizer.ByteLimit {
+		content = content[:tokenizer.ByteLimit]
+	}
+
+	hashes := make([]int, 0, len(content)*order)
+	mask := uint32(buckets - 1)
+	for i := range content {
+		h := uint32(fnvOffset)
+		for n := 0; n < order && i+n < len(content); n++ {
+			h ^= uint32(content[i+n])
+			h *= fnvPrime
+			hashes = append(hashes, int(mix(h)&mask))
+		}
+	}
+	sort.Ints(hashes)
+
+	var f features
+	var norm float64
+	for i := 0; i < len(hashes); {
+		j := i + 1
+		for j < len(hashes) && hashes[j] == hashes[i] {
+			j++
+		}
+
+		value := math.Log1p(float64(j - i))
+		f.buckets = append(f.buckets, hashes[i])
+		f.values = append(f.values, float32(value))
+		norm += value * value
+		i = j
+	}
+
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range f.values {
+			f.values[i] /= float32(norm)
+		}
+	}
+
+	return f
+}
+
+// mix spreads the bits of a FNV-1a hash, whose low bits are the buckets.
+func mix(h uint32) uint32 {
+	h ^= h >> 16
+	h *= 0x7feb352d
+	h ^= h >> 15
+	return h
+}
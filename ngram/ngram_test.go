@@ -0,0 +1,149 @@
+package ngram
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var snippets = map[string][]string{
+	"C": {
+		"#include <stdio.h>\nint main(void) {\n\tprintf(\"%d\\n\", %d);\n\treturn 0;\n}\n",
+		"static int add_%d(int a, int b) {\n\treturn a + b;\n}\n",
+		"struct point_%d {\n\tint x;\n\tint y;\n};\n",
+	},
+	"C++": {
+		"#include <iostream>\nint main() {\n\tstd::cout << %d << std::endl;\n}\n",
+		"template <typename T>\nclass Box%d {\npublic:\n\tT value;\n};\n",
+		"namespace ns%d {\nstd::vector<int> v;\n}\n",
+	},
+	"Python": {
+		"def f%d(x):\n    return x + 1\n",
+		"import os\nprint(os.path.join('a', '%d'))\n",
+		"class Foo%d:\n    def __init__(self):\n        self.x = None\n",
+	},
+}
+
+func testSamples() []Sample {
+	var samples []Sample
+	for language, templates := range snippets {
+		for i := 0; i < 10; i++ {
+			for _, template := range templates {
+				samples = append(samples, Sample{
+					Language: language,
+					Content:  []byte(fmt.Sprintf(template, i)),
+				})
+			}
+		}
+	}
+	return samples
+}
+
+func TestTrain(t *testing.T) {
+	m, err := Train(testSamples(), TrainOptions{Buckets: 1 << 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C", "C++", "Python"}, m.Languages)
+	assert.Equal(t, 4, m.Order)
+
+	tests := []struct {
+		content    string
+		candidates []string
+		expected   []string
+	}{
+		{content: "int main(void) {\n\tprintf(\"hi\");\n}\n", expected: []string{"C", "C++", "Python"}},
+		{content: "std::cout << std::endl;", candidates: []string{"C", "C++"}, expected: []string{"C++", "C"}},
+		{content: "def g(self):\n    return None\n", candidates: []string{"Go", "C", "Python"}, expected: []string{"Python", "C", "Go"}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, m.Classify([]byte(test.content), test.candidates), test.content)
+	}
+
+	probabilities := m.Probabilities([]byte("std::vector"), []string{"C", "Go", "C++"})
+	assert.InDelta(t, 1, probabilities[0]+probabilities[2], 1e-9)
+	assert.Equal(t, 0.0, probabilities[1])
+	assert.True(t, probabilities[2] > probabilities[0])
+}
+
+func TestTrainErrors(t *testing.T) {
+	_, err := Train(nil, TrainOptions{})
+	assert.Equal(t, ErrNoSamples, err)
+
+	_, err = Train(testSamples(), TrainOptions{Buckets: 1000})
+	assert.Error(t, err)
+
+	_, err = Train(testSamples(), TrainOptions{Order: -1})
+	assert.Error(t, err)
+}
+
+func TestTrainReproducible(t *testing.T) {
+	samples := testSamples()
+	m1, err := Train(samples, TrainOptions{Buckets: 1 << 8, Epochs: 2})
+	require.NoError(t, err)
+	m2, err := Train(samples, TrainOptions{Buckets: 1 << 8, Epochs: 2})
+	require.NoError(t, err)
+	assert.Equal(t, m1, m2)
+}
+
+func TestWriteReadModel(t *testing.T) {
+	m, err := Train(testSamples(), TrainOptions{Buckets: 1 << 10})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.True(t, buf.Len() < len(m.weights), "%d bytes", buf.Len())
+
+	read, err := ReadModel(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, m, read)
+
+	_, err = ReadModel(bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	assert.Error(t, err)
+
+	_, err = ReadModel(bytes.NewReader([]byte("not a model")))
+	assert.Equal(t, ErrInvalidModel, err)
+}
+
+func TestReadSamples(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ngram")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"C/hello.c":               "int main;",
+		"C/filenames/Makefile.c":  "int x;",
+		"C/nested/ignored.c":      "int y;",
+		"Python/hello.py":         "print()",
+		"Python/filenames/SConst": "env = 1",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), nil, 0644))
+
+	samples, err := ReadSamples(dir)
+	require.NoError(t, err)
+
+	var got []string
+	for _, sample := range samples {
+		rel, err := filepath.Rel(dir, sample.Filename)
+		require.NoError(t, err)
+		got = append(got, sample.Language+":"+filepath.ToSlash(rel)+":"+string(sample.Content))
+	}
+	assert.Equal(t, []string{
+		"C:C/filenames/Makefile.c:int x;",
+		"C:C/hello.c:int main;",
+		"Python:Python/filenames/SConst:env = 1",
+		"Python:Python/hello.py:print()",
+	}, got)
+}
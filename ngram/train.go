@@ -0,0 +1,247 @@
+package ngram
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Sample is some content of a known language.
+type Sample struct {
+	Language string
+	// Filename is the path of the sample, if it was read from a file.
+	Filename string
+	Content  []byte
+}
+
+// TrainOptions configure the training of a Model.
+// The zero value of every option means its default.
+type TrainOptions struct {
+	// Order is the length of the longest n-grams. Defaults to 4.
+	Order int
+	// Buckets is the number of features n-grams are hashed to. It must be a
+	// power of two, and is the main factor of the size of the model.
+	// Defaults to 1<<14.
+	Buckets int
+	// Epochs is the number of passes of stochastic gradient descent over
+	// the samples. Defaults to 10.
+	Epochs int
+	// LearningRate is the initial step of gradient descent, which decreases
+	// with every epoch. Defaults to 0.5.
+	LearningRate float64
+	// Seed makes the order of the samples in every epoch, and so the model,
+	// reproducible. Defaults to 1.
+	Seed int64
+}
+
+func (o TrainOptions) withDefaults() TrainOptions {
+	if o.Order == 0 {
+		o.Order = 4
+	}
+	if o.Buckets == 0 {
+		o.Buckets = 1 << 14
+	}
+	if o.Epochs == 0 {
+		o.Epochs = 10
+	}
+	if o.LearningRate == 0 {
+		o.LearningRate = 0.5
+	}
+	if o.Seed == 0 {
+		o.Seed = 1
+	}
+	return o
+}
+
+// ErrNoSamples is returned when training a Model without samples.
+var ErrNoSamples = errors.New("ngram: no samples")
+
+// gradientEpsilon is the gradient under which the weights of a language are
+// not updated for a sample, which skips most of the languages once they are
+// told apart from the language of the sample.
+const gradientEpsilon = 1e-4
+
+// Train returns a Model trained on samples with multinomial logistic
+// regression, by stochastic gradient descent on the cross-entropy loss.
+func Train(samples []Sample, opts TrainOptions) (*Model, error) {
+	opts = opts.withDefaults()
+	if opts.Order < 1 {
+		return nil, fmt.Errorf("ngram: invalid order %d", opts.Order)
+	}
+	if opts.Buckets < 1 || opts.Buckets&(opts.Buckets-1) != 0 {
+		return nil, fmt.Errorf("ngram: buckets %d is not a power of two", opts.Buckets)
+	}
+	if len(samples) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	var languages []string
+	seen := make(map[string]bool)
+	for _, sample := range samples {
+		if !seen[sample.Language] {
+			seen[sample.Language] = true
+			languages = append(languages, sample.Language)
+		}
+	}
+	sort.Strings(languages)
+
+	m := newModel(opts.Order, opts.Buckets, languages)
+	labels := make([]int, len(samples))
+	vectors := make([]features, len(samples))
+	for i, sample := range samples {
+		labels[i] = m.index[sample.Language]
+		vectors[i] = extract(sample.Content, opts.Order, opts.Buckets)
+	}
+
+	L := len(languages)
+	bias := make([]float64, L)
+	weights := make([]float32, opts.Buckets*L)
+	scores := make([]float64, L)
+	order := make([]int, len(samples))
+	for i := range order {
+		order[i] = i
+	}
+
+	shuffle := rand.New(rand.NewSource(opts.Seed))
+	for epoch := 0; epoch < opts.Epochs; epoch++ {
+		rate := opts.LearningRate / float64(1+epoch)
+		shuffle.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+		for _, i := range order {
+			f := vectors[i]
+			max := math.Inf(-1)
+			for l := range scores {
+				scores[l] = bias[l]
+			}
+			for j, b := range f.buckets {
+				v := float64(f.values[j])
+				for l, w := range weights[b*L : b*L+L] {
+					scores[l] += v * float64(w)
+				}
+			}
+			for _, score := range scores {
+				if score > max {
+					max = score
+				}
+			}
+
+			var total float64
+			for l, score := range scores {
+				scores[l] = math.Exp(score - max)
+				total += scores[l]
+			}
+
+			for l := range scores {
+				gradient := scores[l] / total
+				if l == labels[i] {
+					gradient--
+				}
+				if math.Abs(gradient) < gradientEpsilon {
+					continue
+				}
+
+				step := rate * gradient
+				bias[l] -= step
+				for j, b := range f.buckets {
+					weights[b*L+l] -= float32(step * float64(f.values[j]))
+				}
+			}
+		}
+	}
+
+	for l := range languages {
+		m.bias[l] = float32(bias[l])
+	}
+	m.quantize(weights)
+	return m, nil
+}
+
+// quantize sets the weights of m to the closest int8 multiples of the
+// largest weight of every language.
+func (m *Model) quantize(weights []float32) {
+	L := len(m.Languages)
+	for l := range m.Languages {
+		var max float32
+		for i := l; i < len(weights); i += L {
+			if w := float32(math.Abs(float64(weights[i]))); w > max {
+				max = w
+			}
+		}
+
+		if max == 0 {
+			continue
+		}
+
+		m.scales[l] = max / 127
+		for i := l; i < len(weights); i += L {
+			m.weights[i] = int8(math.Round(float64(weights[i] / m.scales[l])))
+		}
+	}
+}
+
+// TrainDir trains a Model on the samples under dir, laid out as Linguist
+// samples: a directory per language, named after it, with the samples in it
+// and in its filenames subdirectory.
+func TrainDir(dir string, opts TrainOptions) (*Model, error) {
+	samples, err := ReadSamples(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return Train(samples, opts)
+}
+
+// ReadSamples reads the samples under dir, laid out as Linguist samples.
+// Symbolic links are skipped.
+func ReadSamples(dir string) ([]Sample, error) {
+	const filenamesDir = "filenames"
+
+	langDirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []Sample
+	for _, langDir := range langDirs {
+		if !langDir.IsDir() {
+			continue
+		}
+
+		language := langDir.Name()
+		root := filepath.Join(dir, language)
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() {
+				if path == root || info.Name() == filenamesDir {
+					return nil
+				}
+				return filepath.SkipDir
+			}
+
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			samples = append(samples, Sample{Language: language, Filename: path, Content: content})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return samples, nil
+}
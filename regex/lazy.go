@@ -0,0 +1,56 @@
+package regex
+
+import "sync"
+
+// LazyRegexp is a regular expression compiled on its first use, so that
+// programs importing enry don't pay for the expressions they never run.
+// It is safe for concurrent use by multiple goroutines.
+type LazyRegexp struct {
+	expr    string
+	compile func(string) EnryRegexp
+	once    sync.Once
+	re      EnryRegexp
+}
+
+// Lazy returns a LazyRegexp compiled with MustCompile.
+func Lazy(expr string) *LazyRegexp {
+	return &LazyRegexp{expr: expr, compile: MustCompile}
+}
+
+// LazyMultiline returns a LazyRegexp compiled with MustCompileMultiline.
+func LazyMultiline(expr string) *LazyRegexp {
+	return &LazyRegexp{expr: expr, compile: MustCompileMultiline}
+}
+
+// LazyRuby returns a LazyRegexp compiled with MustCompileRuby.
+func LazyRuby(expr string) *LazyRegexp {
+	return &LazyRegexp{expr: expr, compile: MustCompileRuby}
+}
+
+// Regexp compiles the expression if it was not yet and returns the result.
+// Same as MustCompileRuby, it is nil for a syntax the engine does not support.
+func (l *LazyRegexp) Regexp() EnryRegexp {
+	l.once.Do(func() {
+		l.re = l.compile(l.expr)
+	})
+	return l.re
+}
+
+// Match reports whether b contains any match of the expression.
+// It is always false for a syntax the engine does not support.
+func (l *LazyRegexp) Match(b []byte) bool {
+	re := l.Regexp()
+	return re != nil && re.Match(b)
+}
+
+// MatchString reports whether s contains any match of the expression.
+// It is always false for a syntax the engine does not support.
+func (l *LazyRegexp) MatchString(s string) bool {
+	re := l.Regexp()
+	return re != nil && re.MatchString(s)
+}
+
+// String returns the source of the expression, without compiling it.
+func (l *LazyRegexp) String() string {
+	return l.expr
+}
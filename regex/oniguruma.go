@@ -11,6 +11,10 @@ const Name = Oniguruma
 
 type EnryRegexp = *rubex.Regexp
 
+func init() {
+	Register(oniguruma{})
+}
+
 func MustCompile(s string) EnryRegexp {
 	return rubex.MustCompileASCII(s)
 }
@@ -27,3 +31,27 @@ func MustCompileRuby(s string) EnryRegexp {
 func QuoteMeta(s string) string {
 	return rubex.QuoteMeta(s)
 }
+
+// oniguruma is the Engine for the cgo bindings to the Oniguruma library.
+type oniguruma struct{}
+
+func (oniguruma) Name() string {
+	return Oniguruma
+}
+
+func (oniguruma) Compile(expr string) (Regexp, error) {
+	re, err := rubex.NewRegexpASCII(expr, rubex.ONIG_OPTION_DEFAULT)
+	if err != nil {
+		return nil, err
+	}
+
+	return re, nil
+}
+
+func (e oniguruma) CompileMultiline(expr string) (Regexp, error) {
+	return e.Compile(expr)
+}
+
+func (e oniguruma) CompileRuby(expr string) (Regexp, error) {
+	return e.Compile(expr)
+}
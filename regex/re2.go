@@ -0,0 +1,41 @@
+package regex
+
+import (
+	"regexp"
+)
+
+func init() {
+	Register(re2{})
+}
+
+// re2 is the Engine for the standard library regexp package.
+// It is always available, whatever the build tags are.
+type re2 struct{}
+
+func (re2) Name() string {
+	return RE2
+}
+
+func (re2) Compile(expr string) (Regexp, error) {
+	return compileRE2(expr)
+}
+
+func (re2) CompileMultiline(expr string) (Regexp, error) {
+	const multilineModeFlag = "(?m)"
+	return compileRE2(multilineModeFlag + expr)
+}
+
+// CompileRuby always fails, same as MustCompileRuby returns nil on RE2.
+func (re2) CompileRuby(expr string) (Regexp, error) {
+	return nil, ErrUnsupportedSyntax
+}
+
+// compileRE2 avoids returning a non-nil Regexp holding a nil *regexp.Regexp.
+func compileRE2(expr string) (Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return re, nil
+}
@@ -2,8 +2,104 @@ package regex
 
 // Package regex abstracts regular expression engine
 // that can be chosen at compile-time by a build tag.
+//
+// Every engine linked into the binary also registers itself as an Engine,
+// so that callers can pick one at runtime, e.g. to compare the results of
+// RE2 and Oniguruma within a single process.
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
 
 const (
 	RE2       = "RE2"
 	Oniguruma = "Oniguruma"
 )
+
+// ErrUnsupportedSyntax is returned by an Engine for expressions that use
+// syntax the engine can not handle, e.g. lookarounds on RE2.
+var ErrUnsupportedSyntax = errors.New("regex: syntax is not supported by the engine")
+
+// Regexp is a compiled regular expression of any Engine.
+type Regexp interface {
+	Match(b []byte) bool
+	MatchString(s string) bool
+	String() string
+}
+
+// Engine is a regular expression implementation enry can run on.
+// Its methods mirror MustCompile, MustCompileMultiline and MustCompileRuby,
+// but report errors instead of panicking.
+type Engine interface {
+	// Name returns the name the engine is registered with.
+	Name() string
+	// Compile compiles an expression with the default flags of the engine.
+	Compile(expr string) (Regexp, error)
+	// CompileMultiline compiles an expression where ^$ match begin/end of line.
+	CompileMultiline(expr string) (Regexp, error)
+	// CompileRuby compiles an expression written in Ruby syntax.
+	CompileRuby(expr string) (Regexp, error)
+}
+
+var (
+	enginesMu sync.RWMutex
+	engines   = make(map[string]Engine)
+)
+
+// Register makes an engine available by its name.
+// It panics if it is called twice with the same name or if engine is nil.
+func Register(engine Engine) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+
+	if engine == nil {
+		panic("regex: Register engine is nil")
+	}
+
+	name := engine.Name()
+	if _, dup := engines[name]; dup {
+		panic("regex: Register called twice for engine " + name)
+	}
+
+	engines[name] = engine
+}
+
+// Lookup returns the engine registered with the given name.
+func Lookup(name string) (Engine, error) {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+
+	engine, ok := engines[name]
+	if !ok {
+		return nil, fmt.Errorf("regex: unknown engine %q (forgotten build tag?)", name)
+	}
+
+	return engine, nil
+}
+
+// Engines returns a sorted list of the names of the registered engines.
+func Engines() []string {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Default returns the engine selected at compile-time, the one with Name.
+func Default() Engine {
+	engine, err := Lookup(Name)
+	if err != nil {
+		panic(err)
+	}
+
+	return engine
+}
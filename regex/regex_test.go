@@ -0,0 +1,56 @@
+package regex
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngines(t *testing.T) {
+	assert.Contains(t, Engines(), RE2)
+	assert.Contains(t, Engines(), Name)
+	assert.Equal(t, Name, Default().Name())
+
+	_, err := Lookup("no-such-engine")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { Register(re2{}) }, "duplicate registration must panic")
+}
+
+func TestRE2Engine(t *testing.T) {
+	engine, err := Lookup(RE2)
+	require.NoError(t, err)
+
+	re, err := engine.Compile(`^b`)
+	require.NoError(t, err)
+	assert.False(t, re.MatchString("a\nb"))
+
+	re, err = engine.CompileMultiline(`^b`)
+	require.NoError(t, err)
+	assert.True(t, re.MatchString("a\nb"))
+
+	_, err = engine.CompileRuby(`(?<!a)b`)
+	assert.True(t, errors.Is(err, ErrUnsupportedSyntax))
+
+	re, err = engine.Compile(`(`)
+	assert.Error(t, err)
+	assert.Nil(t, re)
+}
+
+func TestLazy(t *testing.T) {
+	l := LazyMultiline(`^b`)
+	assert.Equal(t, `^b`, l.String())
+	assert.True(t, l.MatchString("a\nb"))
+	assert.True(t, l.Match([]byte("a\nb")))
+	assert.Same(t, l.Regexp(), l.Regexp(), "must be compiled only once")
+
+	assert.Panics(t, func() { Lazy(`(`).Regexp() })
+
+	r := LazyRuby(`(?<!a)b`)
+	if Name == RE2 {
+		assert.Nil(t, r.Regexp())
+	}
+	assert.Equal(t, Name != RE2, r.MatchString("b"))
+}
@@ -0,0 +1,185 @@
+package regex
+
+import "sync"
+
+// Set matches a string against many expressions at once and reports which
+// one matched, for the common case where most strings match none of them.
+//
+// Every expression can come with a literal that all of its matches contain.
+// Before running any expression, a single Aho-Corasick automaton over all
+// the literals filters out the expressions which can not possibly match.
+// Expressions without a literal are always run.
+//
+// A Set is safe for concurrent use by multiple goroutines.
+type Set struct {
+	matchers []*LazyRegexp
+	literals []string
+
+	once      sync.Once
+	prefilter *literalsAutomaton
+}
+
+// NewSet returns a Set of the given expressions. If not nil, literals must have
+// an element for each of the matchers, an empty one meaning no literal is known.
+// It panics otherwise.
+func NewSet(matchers []*LazyRegexp, literals []string) *Set {
+	if literals != nil && len(literals) != len(matchers) {
+		panic("regex: NewSet needs a literal for each of the matchers")
+	}
+
+	return &Set{matchers: matchers, literals: literals}
+}
+
+// MatchString reports whether s contains any match of any of the expressions.
+func (s *Set) MatchString(str string) bool {
+	return s.FindString(str) >= 0
+}
+
+// FindString returns the index of the first expression, in the order they were
+// given to NewSet, that matches str. It returns -1 if none of them does.
+func (s *Set) FindString(str string) int {
+	s.once.Do(func() {
+		s.prefilter = newLiteralsAutomaton(s.literals)
+	})
+
+	// a bit set of the expressions whose literal was found in str
+	var small [4]uint64
+	found := small[:]
+	if words := (len(s.matchers) + 63) / 64; words > len(small) {
+		found = make([]uint64, words)
+	}
+
+	if !s.prefilter.find(str, found) {
+		return -1
+	}
+
+	for i, m := range s.matchers {
+		if s.prefilter.hasLiteral(i) && found[i/64]&(1<<(uint(i)%64)) == 0 {
+			continue
+		}
+		if m.MatchString(str) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Len returns the number of expressions in the Set.
+func (s *Set) Len() int {
+	return len(s.matchers)
+}
+
+// Expr returns the source of the i-th expression in the Set.
+func (s *Set) Expr(i int) string {
+	return s.matchers[i].String()
+}
+
+// literalsAutomaton is an Aho-Corasick automaton finding all the occurrences
+// of a number of literals in a single pass over a string.
+type literalsAutomaton struct {
+	nodes []acNode
+	// withLiteral tells the literals which are not empty
+	withLiteral []bool
+	// alwaysRun tells if some expressions have no literal to prefilter on
+	alwaysRun bool
+}
+
+type acNode struct {
+	next map[byte]int32
+	fail int32
+	// outputs are the indexes of the literals ending at this node,
+	// including the ones reachable through fail links
+	outputs []int
+}
+
+func newLiteralsAutomaton(literals []string) *literalsAutomaton {
+	a := &literalsAutomaton{
+		nodes:       []acNode{{}},
+		withLiteral: make([]bool, len(literals)),
+		alwaysRun:   literals == nil,
+	}
+
+	for i, literal := range literals {
+		if literal == "" {
+			a.alwaysRun = true
+			continue
+		}
+
+		a.withLiteral[i] = true
+		node := int32(0)
+		for j := 0; j < len(literal); j++ {
+			next, ok := a.nodes[node].next[literal[j]]
+			if !ok {
+				next = int32(len(a.nodes))
+				a.nodes = append(a.nodes, acNode{})
+				if a.nodes[node].next == nil {
+					a.nodes[node].next = make(map[byte]int32)
+				}
+				a.nodes[node].next[literal[j]] = next
+			}
+			node = next
+		}
+		a.nodes[node].outputs = append(a.nodes[node].outputs, i)
+	}
+
+	// breadth-first, so that fail links always point to nodes already done
+	queue := make([]int32, 0, len(a.nodes))
+	for _, child := range a.nodes[0].next {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for b, child := range a.nodes[node].next {
+			fail := a.nodes[node].fail
+			for {
+				if next, ok := a.nodes[fail].next[b]; ok && next != child {
+					a.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					break
+				}
+				fail = a.nodes[fail].fail
+			}
+
+			failOutputs := a.nodes[a.nodes[child].fail].outputs
+			a.nodes[child].outputs = append(a.nodes[child].outputs, failOutputs...)
+			queue = append(queue, child)
+		}
+	}
+
+	return a
+}
+
+func (a *literalsAutomaton) hasLiteral(i int) bool {
+	return i < len(a.withLiteral) && a.withLiteral[i]
+}
+
+// find sets the bits of the literals found in s. It reports whether any
+// expression may match: a literal was found or some have no literal.
+func (a *literalsAutomaton) find(s string, found []uint64) bool {
+	any := a.alwaysRun
+	node := int32(0)
+	for i := 0; i < len(s); i++ {
+		for {
+			if next, ok := a.nodes[node].next[s[i]]; ok {
+				node = next
+				break
+			}
+			if node == 0 {
+				break
+			}
+			node = a.nodes[node].fail
+		}
+
+		for _, out := range a.nodes[node].outputs {
+			found[out/64] |= 1 << (uint(out) % 64)
+			any = true
+		}
+	}
+
+	return any
+}
@@ -0,0 +1,61 @@
+package regex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	matchers := []*LazyRegexp{
+		Lazy(`(^|/)node_modules/`),
+		Lazy(`(^|/)[Dd]ocs?/`),
+		Lazy(`\.min\.js$`),
+		Lazy(`(^|/)modules/`),
+		Lazy(`^a+b$`),
+	}
+	literals := []string{"node_modules/", "oc", ".min.js", "modules/", ""}
+
+	tests := []struct {
+		str      string
+		expected int
+	}{
+		{str: "", expected: -1},
+		{str: "src/main.go", expected: -1},
+		{str: "web/node_modules/x.js", expected: 0},
+		{str: "node_modules/x.min.js", expected: 0},
+		{str: "Docs/index.md", expected: 1},
+		{str: "doc", expected: -1},
+		{str: "dist/x.min.js", expected: 2},
+		{str: "go/modules/x.go", expected: 3},
+		{str: "aab", expected: 4},
+	}
+
+	for _, literals := range [][]string{literals, nil} {
+		set := NewSet(matchers, literals)
+		assert.Equal(t, len(matchers), set.Len())
+		assert.Equal(t, `\.min\.js$`, set.Expr(2))
+		for _, test := range tests {
+			assert.Equal(t, test.expected, set.FindString(test.str), "FindString(%q)", test.str)
+			assert.Equal(t, test.expected >= 0, set.MatchString(test.str), "MatchString(%q)", test.str)
+		}
+	}
+
+	assert.Panics(t, func() { NewSet(matchers, literals[1:]) })
+}
+
+func TestLiteralsAutomaton(t *testing.T) {
+	literals := []string{"he", "she", "his", "hers", "", "s"}
+	a := newLiteralsAutomaton(literals)
+
+	found := make([]uint64, 1)
+	assert.True(t, a.find("ushers", found))
+	// "she", "he", "hers" and "s" occur in "ushers", "his" does not
+	assert.Equal(t, uint64(1<<0|1<<1|1<<3|1<<5), found[0])
+	assert.False(t, a.hasLiteral(4))
+
+	a = newLiteralsAutomaton([]string{"abc"})
+	found[0] = 0
+	assert.False(t, a.find("ab bc", found))
+	assert.True(t, a.find("xxabcx", found))
+}
@@ -0,0 +1,164 @@
+package tokenizer
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Options configure which parts of some content are tokenized, e.g. to
+// tokenize less of large generated files, or to look past license headers.
+// The zero value tokenizes the first ByteLimit bytes, same as Tokenize.
+type Options struct {
+	// Limit is the maximum number of bytes to tokenize. Zero means ByteLimit
+	// and a negative limit means the whole content.
+	Limit int
+	// Sampler selects the parts of the content to tokenize within Limit.
+	// Nil means Head.
+	Sampler Sampler
+	// SkipLeadingComments ignores everything before the first token, e.g.
+	// a license header, so that Limit only applies to what follows.
+	SkipLeadingComments bool
+}
+
+// Range is a part of some content, content[Start:End].
+type Range struct {
+	Start, End int
+}
+
+// Sampler selects the parts of some content to tokenize. The samplers of
+// this package are also fmt.Stringers, describing their parameters, so that
+// options can be told apart, e.g. in the keys of a cache.
+type Sampler interface {
+	// Sample appends to ranges the parts of content to tokenize, at most
+	// limit bytes in total, and returns the extended slice. The ranges must
+	// be sorted and must not overlap. Each of them is tokenized as if it was
+	// all the content.
+	Sample(ranges []Range, content []byte, limit int) []Range
+}
+
+// SamplerFunc is an adapter to use an ordinary function as a Sampler.
+type SamplerFunc func(ranges []Range, content []byte, limit int) []Range
+
+// Sample implements Sampler.
+func (f SamplerFunc) Sample(ranges []Range, content []byte, limit int) []Range {
+	return f(ranges, content, limit)
+}
+
+// Head samples the first limit bytes of content.
+var Head Sampler = head{}
+
+type head struct{}
+
+func (head) Sample(ranges []Range, content []byte, limit int) []Range {
+	if len(content) < limit {
+		limit = len(content)
+	}
+	return append(ranges, Range{0, limit})
+}
+
+func (head) String() string {
+	return "head"
+}
+
+// HeadTail samples the first and the last limit/2 bytes of content.
+// The tail starts at the beginning of a line when possible.
+var HeadTail Sampler = headTail{}
+
+type headTail struct{}
+
+func (headTail) Sample(ranges []Range, content []byte, limit int) []Range {
+	if len(content) <= limit {
+		return Head.Sample(ranges, content, limit)
+	}
+
+	head := limit / 2
+	tail := lineStart(content, len(content)-(limit-head), len(content))
+	return append(ranges, Range{0, head}, Range{tail, len(content)})
+}
+
+func (headTail) String() string {
+	return "head-tail"
+}
+
+// Windows returns a Sampler of n windows of limit/n bytes, spread evenly over
+// the content from its start to its end. The windows start at the beginning
+// of a line when possible.
+func Windows(n int) Sampler {
+	if n < 2 {
+		return Head
+	}
+	return windows(n)
+}
+
+// windows is the number of windows of a Windows sampler.
+type windows int
+
+func (n windows) Sample(ranges []Range, content []byte, limit int) []Range {
+	if len(content) <= limit {
+		return Head.Sample(ranges, content, limit)
+	}
+
+	size := limit / int(n)
+	for i := 0; i < int(n); i++ {
+		start := i * (len(content) - size) / (int(n) - 1)
+		end := start + size
+		ranges = append(ranges, Range{lineStart(content, start, end), end})
+	}
+	return ranges
+}
+
+func (n windows) String() string {
+	return fmt.Sprintf("windows(%d)", int(n))
+}
+
+// lineStart returns the start of the first line beginning within content[i:end],
+// or i if there is none.
+func lineStart(content []byte, i, end int) int {
+	if i == 0 || content[i-1] == '\n' {
+		return i
+	}
+
+	if eol := bytes.IndexByte(content[i:end], '\n'); eol >= 0 && i+eol+1 < end {
+		return i + eol + 1
+	}
+	return i
+}
+
+func (o Options) limit(content []byte) int {
+	switch {
+	case o.Limit < 0:
+		return len(content)
+	case o.Limit == 0:
+		return ByteLimit
+	}
+	return o.Limit
+}
+
+// AppendRanges appends to ranges the parts of content to tokenize with
+// these options, and returns the extended slice.
+func (o Options) AppendRanges(ranges []Range, content []byte) []Range {
+	offset := 0
+	if o.SkipLeadingComments {
+		var l lexer
+		l.reset(content)
+		if !l.next() {
+			return ranges
+		}
+
+		offset = l.token.start
+		content = content[offset:]
+	}
+
+	sampler := o.Sampler
+	if sampler == nil {
+		sampler = Head
+	}
+
+	n := len(ranges)
+	ranges = sampler.Sample(ranges, content, o.limit(content))
+	for i := n; i < len(ranges); i++ {
+		ranges[i].Start += offset
+		ranges[i].End += offset
+	}
+	return ranges
+}
@@ -0,0 +1,67 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplers(t *testing.T) {
+	content := []byte(strings.Repeat("line\n", 20)) // 100 bytes
+
+	tests := []struct {
+		name     string
+		opts     Options
+		expected []Range
+	}{
+		{name: "default", opts: Options{}, expected: []Range{{0, 100}}},
+		{name: "head", opts: Options{Limit: 12}, expected: []Range{{0, 12}}},
+		{name: "no limit", opts: Options{Limit: -1, Sampler: HeadTail}, expected: []Range{{0, 100}}},
+		{name: "head tail", opts: Options{Limit: 20, Sampler: HeadTail}, expected: []Range{{0, 10}, {90, 100}}},
+		{name: "head tail line", opts: Options{Limit: 22, Sampler: HeadTail}, expected: []Range{{0, 11}, {90, 100}}},
+		{name: "one window", opts: Options{Limit: 10, Sampler: Windows(1)}, expected: []Range{{0, 10}}},
+		{name: "windows", opts: Options{Limit: 30, Sampler: Windows(3)}, expected: []Range{{0, 10}, {45, 55}, {90, 100}}},
+		{name: "windows larger", opts: Options{Limit: 200, Sampler: Windows(3)}, expected: []Range{{0, 100}}},
+		{name: "func", opts: Options{Limit: 10, Sampler: SamplerFunc(func(ranges []Range, content []byte, limit int) []Range {
+			return append(ranges, Range{len(content) - limit, len(content)})
+		})}, expected: []Range{{90, 100}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.opts.AppendRanges(nil, content))
+		})
+	}
+}
+
+func TestSamplerStrings(t *testing.T) {
+	tests := []struct {
+		sampler  Sampler
+		expected string
+	}{
+		{Head, "head"},
+		{HeadTail, "head-tail"},
+		{Windows(4), "windows(4)"},
+		{Windows(1), "head"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.sampler.(fmt.Stringer).String())
+	}
+}
+
+func TestTokenizeOptions(t *testing.T) {
+	content := []byte("/*\n * License\n */\n# a comment\nfoo bar\nbaz qux\n")
+
+	assert.Equal(t, []string{"foo", "bar", "baz", "qux"}, Strings(TokenizeOptions(content, Options{})))
+	assert.Equal(t, []string{"foo"}, Strings(TokenizeOptions(content, Options{Limit: 34})))
+	assert.Equal(t, []string{"foo", "bar"}, Strings(TokenizeOptions(content, Options{Limit: 7, SkipLeadingComments: true})))
+	assert.Equal(t, []string{"foo", "qux"}, Strings(TokenizeOptions(content, Options{Limit: 8, Sampler: HeadTail, SkipLeadingComments: true})))
+
+	tokens := TokenizeOptions(content, Options{Limit: 8, Sampler: Windows(2), SkipLeadingComments: true})
+	assert.Equal(t, []Token{{Regular, "foo", 30, 33}, {Regular, "qux", 42, 45}}, tokens)
+
+	assert.Empty(t, TokenizeOptions([]byte("/* only a comment */"), Options{SkipLeadingComments: true}))
+}
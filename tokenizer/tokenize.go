@@ -0,0 +1,568 @@
+package tokenizer
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// Tokenize returns lexical tokens from content, in the order they appear in it.
+// The tokens returned match what the Linguist library returns. At most the
+// first ByteLimit bytes of content are tokenized.
+//
+// It is a port of the rules of Linguist's flex lexer, see
+// https://github.com/github/linguist/blob/master/ext/linguist/tokenizer.l
+func Tokenize(content []byte) []Token {
+	return TokenizeOptions(content, Options{})
+}
+
+// TokenizeOptions is the same as Tokenize, for the parts of content
+// selected by the given options.
+func TokenizeOptions(content []byte, opts Options) []Token {
+	tokens := make([]Token, 0, 50)
+
+	var s Scanner
+	s.ResetOptions(content, opts)
+	for s.Scan() {
+		start, end := s.Offsets()
+		tokens = append(tokens, Token{Kind: s.Kind(), Value: string(s.Bytes()), Start: start, End: end})
+	}
+
+	return tokens
+}
+
+// Scanner reads the tokens of some content one by one, same as Tokenize
+// returns them but without allocating, e.g.
+//
+//	var s tokenizer.Scanner
+//	s.Reset(content)
+//	for s.Scan() {
+//		fmt.Printf("%s %s\n", s.Kind(), s.Bytes())
+//	}
+//
+// The zero value is a Scanner of no content.
+type Scanner struct {
+	lexer
+	content []byte
+	ranges  []Range
+	// scanned is the number of ranges scanned so far
+	scanned int
+	value   []byte
+}
+
+// Reset makes the Scanner read the tokens of content, from the start.
+// At most the first ByteLimit bytes of content are tokenized.
+func (s *Scanner) Reset(content []byte) {
+	s.ResetOptions(content, Options{})
+}
+
+// ResetOptions makes the Scanner read the tokens of the parts of content
+// selected by the given options.
+func (s *Scanner) ResetOptions(content []byte, opts Options) {
+	s.content = content
+	s.ranges = opts.AppendRanges(s.ranges[:0], content)
+	s.scanned = 0
+	s.lexer = lexer{}
+}
+
+// Scan advances to the next token, and reports whether there is one.
+func (s *Scanner) Scan() bool {
+	for !s.next() {
+		if s.scanned == len(s.ranges) {
+			return false
+		}
+
+		r := s.ranges[s.scanned]
+		s.scanned++
+		s.reset(s.content[:r.End])
+		s.pos = r.Start
+	}
+
+	return true
+}
+
+// Kind returns the kind of the current token.
+func (s *Scanner) Kind() Kind {
+	return s.token.kind
+}
+
+// Bytes returns the value of the current token, same as Token.Value.
+// It may point into the content or into a buffer of the Scanner, so
+// it is only valid until the next call to Scan.
+func (s *Scanner) Bytes() []byte {
+	t := s.token
+	switch t.kind {
+	case Shebang:
+		s.value = append(append(s.value[:0], shebangPrefix...), s.content[t.start:t.end]...)
+		return s.value
+	case SGMLTag:
+		s.value = append(append(s.value[:0], s.content[t.start:t.end]...), '>')
+		return s.value
+	}
+
+	return s.content[t.start:t.end]
+}
+
+// Offsets returns the start and end offsets of the current token, same as
+// Token.Start and Token.End.
+func (s *Scanner) Offsets() (start, end int) {
+	return s.token.start, s.token.end
+}
+
+const shebangPrefix = "SHEBANG#!"
+
+// maxTokenLen is the length above which Linguist drops tokens.
+const maxTokenLen = 32
+
+// condition is a flex start condition, i.e. the set of rules the lexer runs.
+type condition int
+
+const (
+	initial condition = iota
+	sgml
+	cComment
+	xmlComment
+	haskellComment
+	ocamlComment
+	pythonDComment
+	pythonSComment
+)
+
+// commentEnds are the delimiters closing each of the comment conditions.
+var commentEnds = map[condition]string{
+	cComment:       "*/",
+	xmlComment:     "-->",
+	haskellComment: "-}",
+	ocamlComment:   "*)",
+	pythonDComment: `"""`,
+	pythonSComment: `'''`,
+}
+
+// rule is a rule of the INITIAL condition, in the order of tokenizer.l,
+// which breaks ties between matches of the same length.
+type rule int
+
+const (
+	ruleShebangEnv rule = iota
+	ruleShebang
+	ruleLineComment
+	ruleCComment
+	ruleHaskellComment
+	ruleOCamlComment
+	rulePythonDComment
+	rulePythonSComment
+	ruleEmptyString
+	ruleDoubleQuote
+	ruleSingleQuote
+	ruleNumber
+	ruleSGMLTag
+	rulePunctuation
+	ruleRegular
+	ruleOperator
+	ruleAny
+)
+
+// lexer holds the state of the flex scanner: the input position, the start
+// condition and whether the position is at the beginning of a line.
+type lexer struct {
+	content []byte
+	pos     int
+	bol     bool
+	cond    condition
+
+	// token is the last token found, if fed is set
+	token struct {
+		kind       Kind
+		start, end int
+	}
+	fed bool
+	// stopped is set once an action stopped the scanner
+	stopped bool
+}
+
+// reset makes the lexer start over on content.
+func (l *lexer) reset(content []byte) {
+	*l = lexer{content: content, bol: true}
+}
+
+// next runs the rules until one of them feeds a token, and reports whether one did.
+func (l *lexer) next() bool {
+	l.fed = false
+	for !l.fed && !l.stopped && l.pos < len(l.content) {
+		var ok bool
+		switch l.cond {
+		case initial:
+			ok = l.scanInitial()
+		case sgml:
+			ok = l.scanSGML()
+		default:
+			l.scanComment()
+			ok = true
+		}
+
+		l.stopped = !ok
+	}
+
+	return l.fed
+}
+
+// match consumes the n bytes matched by a rule, and returns them.
+func (l *lexer) match(n int) []byte {
+	text := l.content[l.pos : l.pos+n]
+	l.pos += n
+	l.bol = text[n-1] == '\n'
+	return text
+}
+
+// input consumes the next byte, same as input() in flex actions. It reports
+// false at the end of the content or on a NUL byte, which stop the scanner.
+func (l *lexer) input() (byte, bool) {
+	if l.pos >= len(l.content) {
+		return 0, false
+	}
+
+	c := l.content[l.pos]
+	l.pos++
+	l.bol = c == '\n'
+	return c, c != 0
+}
+
+func (l *lexer) eatUntilEOL() bool {
+	for {
+		c, ok := l.input()
+		if !ok {
+			return false
+		}
+		if c == '\n' {
+			return true
+		}
+	}
+}
+
+func (l *lexer) eatUntilUnescaped(q byte) bool {
+	for {
+		c, ok := l.input()
+		if !ok {
+			return false
+		}
+
+		switch c {
+		case '\n':
+			return true
+		case '\\':
+			if _, ok := l.input(); !ok {
+				return false
+			}
+		case q:
+			return true
+		}
+	}
+}
+
+// feed sets the token found at content[start:end], unless it is longer than
+// Linguist allows.
+func (l *lexer) feed(kind Kind, start, end int) {
+	if end-start > maxTokenLen {
+		return
+	}
+
+	l.token.kind, l.token.start, l.token.end = kind, start, end
+	l.fed = true
+}
+
+func (l *lexer) scanInitial() bool {
+	r, n := l.matchInitial(l.content[l.pos:])
+	start := l.pos
+	text := l.match(n)
+
+	switch r {
+	case ruleShebangEnv:
+		// the interpreter follows the last space, as Linguist's strrchr does
+		text = cString(text)
+		off := bytes.LastIndexByte(text, ' ') + 1
+		l.feed(Shebang, start+off, start+len(text))
+		return l.eatUntilEOL()
+	case ruleShebang:
+		off := bytes.LastIndexByte(text, '/') + 1
+		if string(text[off:]) != "env" {
+			l.feed(Shebang, start+off, start+len(text))
+		}
+		return l.eatUntilEOL()
+	case ruleCComment:
+		l.cond = cComment
+	case ruleHaskellComment:
+		l.cond = haskellComment
+	case ruleOCamlComment:
+		l.cond = ocamlComment
+	case rulePythonDComment:
+		l.cond = pythonDComment
+	case rulePythonSComment:
+		l.cond = pythonSComment
+	case ruleDoubleQuote:
+		return l.eatUntilUnescaped('"')
+	case ruleSingleQuote:
+		return l.eatUntilUnescaped('\'')
+	case ruleSGMLTag:
+		if string(text) == "<!--" {
+			l.cond = xmlComment
+			break
+		}
+		l.feed(SGMLTag, start, l.pos)
+		l.cond = sgml
+	case rulePunctuation:
+		l.feed(Punctuation, start, l.pos)
+	case ruleRegular:
+		if bytes.HasPrefix(text, []byte("/*")) {
+			if len(text) < 4 || !bytes.HasSuffix(text, []byte("*/")) {
+				l.cond = cComment
+			}
+			break
+		}
+		l.feed(Regular, start, l.pos)
+	case ruleOperator:
+		l.feed(Operator, start, l.pos)
+	}
+
+	return true
+}
+
+// matchInitial returns the rule of the INITIAL condition matching the longest
+// prefix of s, and the length of that prefix. As in flex, the first rule wins
+// among the ones matching as many bytes.
+func (l *lexer) matchInitial(s []byte) (rule, int) {
+	best, longest := ruleAny, 1
+	try := func(r rule, n int) {
+		if n > longest || (n == longest && r < best) {
+			best, longest = r, n
+		}
+	}
+
+	if l.bol {
+		if bytes.HasPrefix(s, []byte("#!")) {
+			try(ruleShebangEnv, len(reShebangEnv.Find(s)))
+			try(ruleShebang, matchShebang(s))
+		}
+		try(ruleLineComment, matchLineComment(s))
+	}
+
+	try(ruleCComment, matchLiteral(s, "/*"))
+	try(ruleHaskellComment, matchLiteral(s, "{-"))
+	try(ruleOCamlComment, matchLiteral(s, "(*"))
+	try(rulePythonDComment, matchLiteral(s, `"""`))
+	try(rulePythonSComment, matchLiteral(s, "'''"))
+	try(ruleEmptyString, matchLiteral(s, `""`))
+	try(ruleEmptyString, matchLiteral(s, "''"))
+	try(ruleDoubleQuote, matchLiteral(s, `"`))
+	try(ruleSingleQuote, matchLiteral(s, "'"))
+	try(ruleNumber, matchNumber(s))
+	if len(s) > 0 && s[0] == '<' {
+		if n := span(s[1:], isSGMLTag); n > 0 {
+			try(ruleSGMLTag, 1+n)
+		}
+	}
+	if len(s) > 0 && isPunctuation(s[0]) {
+		try(rulePunctuation, 1)
+	}
+	try(ruleRegular, span(s, isRegular))
+	try(ruleOperator, matchOperator(s))
+
+	return best, longest
+}
+
+func (l *lexer) scanSGML() bool {
+	s := l.content[l.pos:]
+	start := l.pos
+
+	n := span(s, isWord)
+	if n == 0 {
+		// > ends the tag, anything else is skipped
+		if l.match(1)[0] == '>' {
+			l.cond = initial
+		}
+		return true
+	}
+
+	if n == len(s) || s[n] != '=' {
+		l.match(n)
+		l.feed(SGMLAttribute, start, l.pos)
+		return true
+	}
+
+	if n+1 < len(s) && (s[n+1] == '"' || s[n+1] == '\'') {
+		quote := s[n+1]
+		l.match(n + 2)
+		l.feed(SGMLAttribute, start, start+n+1)
+		return l.eatUntilUnescaped(quote)
+	}
+
+	// the value is not part of the token
+	l.match(n + 1 + span(s[n+1:], isWord))
+	l.feed(SGMLAttribute, start, start+n+1)
+	return true
+}
+
+func (l *lexer) scanComment() {
+	end := commentEnds[l.cond]
+	if bytes.HasPrefix(l.content[l.pos:], []byte(end)) {
+		l.match(len(end))
+		l.cond = initial
+		return
+	}
+
+	l.match(1)
+}
+
+// reShebangEnv is the only rule with enough backtracking to be worth a regexp.
+// Longest makes it match the same as flex.
+var reShebangEnv = func() *regexp.Regexp {
+	re := regexp.MustCompile(`\A#![ \t]*([[:alnum:]_/]*/)?env([ \t]+([^ \t=]*=[^ \t]*))*[ \t]+[[:alpha:]_]+`)
+	re.Longest()
+	return re
+}()
+
+// matchShebang matches ^#![ \t]*[[:alpha:]_\/]+
+func matchShebang(s []byte) int {
+	n := 2 + span(s[2:], isBlank)
+	if m := span(s[n:], isShebang); m > 0 {
+		return n + m
+	}
+	return 0
+}
+
+// matchLineComment matches ^[ \t]*(\/\/|--|\#|%|\")" ".*
+func matchLineComment(s []byte) int {
+	n := span(s, isBlank)
+	switch {
+	case bytes.HasPrefix(s[n:], []byte("// ")), bytes.HasPrefix(s[n:], []byte("-- ")):
+		n += 3
+	case bytes.HasPrefix(s[n:], []byte("# ")), bytes.HasPrefix(s[n:], []byte("% ")), bytes.HasPrefix(s[n:], []byte(`" `)):
+		n += 2
+	default:
+		return 0
+	}
+
+	if eol := bytes.IndexByte(s[n:], '\n'); eol >= 0 {
+		return n + eol
+	}
+	return len(s)
+}
+
+// matchNumber matches
+// (0x[0-9a-fA-F]([0-9a-fA-F]|\.)*|[0-9]([0-9]|\.)*)([uU][lL]{0,2}|([eE][-+][0-9]*)?[fFlL]*)
+// Suffixes may start with hexadecimal digits, so every length of the number
+// is tried to find the longest match.
+func matchNumber(s []byte) int {
+	longest := 0
+	try := func(min, max int) {
+		for n := min; n <= max; n++ {
+			if m := n + matchNumberSuffix(s[n:]); m > longest {
+				longest = m
+			}
+		}
+	}
+
+	if len(s) > 2 && s[0] == '0' && s[1] == 'x' && isHex(s[2]) {
+		try(3, 3+span(s[3:], isHexOrDot))
+	}
+	if len(s) > 0 && isDigit(s[0]) {
+		try(1, 1+span(s[1:], isDigitOrDot))
+	}
+
+	return longest
+}
+
+func matchNumberSuffix(s []byte) int {
+	if len(s) == 0 {
+		return 0
+	}
+
+	switch s[0] {
+	case 'u', 'U':
+		n := 1 + span(s[1:], isL)
+		if n > 3 {
+			n = 3
+		}
+		return n
+	case 'e', 'E':
+		if len(s) > 1 && (s[1] == '-' || s[1] == '+') {
+			n := 2 + span(s[2:], isDigit)
+			return n + span(s[n:], isFloatSuffix)
+		}
+		return 0
+	}
+
+	return span(s, isFloatSuffix)
+}
+
+// matchOperator matches \<\<?|\+|\-|\*|\/|%|&&?|\|\|?
+func matchOperator(s []byte) int {
+	if len(s) == 0 {
+		return 0
+	}
+
+	switch s[0] {
+	case '<', '&', '|':
+		if len(s) > 1 && s[1] == s[0] {
+			return 2
+		}
+		return 1
+	case '+', '-', '*', '/', '%':
+		return 1
+	}
+
+	return 0
+}
+
+func matchLiteral(s []byte, literal string) int {
+	if bytes.HasPrefix(s, []byte(literal)) {
+		return len(literal)
+	}
+	return 0
+}
+
+// span returns the length of the prefix of s whose bytes all satisfy f.
+func span(s []byte, f func(byte) bool) int {
+	for i, c := range s {
+		if !f(c) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// cString returns s up to its first NUL byte, as C string functions see it.
+func cString(s []byte) []byte {
+	if i := bytes.IndexByte(s, 0); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+func isAlpha(c byte) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' }
+func isHex(c byte) bool   { return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F' }
+func isWord(c byte) bool  { return isAlpha(c) || isDigit(c) || c == '_' }
+func isBlank(c byte) bool { return c == ' ' || c == '\t' }
+func isL(c byte) bool     { return c == 'l' || c == 'L' }
+
+func isHexOrDot(c byte) bool    { return isHex(c) || c == '.' }
+func isDigitOrDot(c byte) bool  { return isDigit(c) || c == '.' }
+func isFloatSuffix(c byte) bool { return c == 'f' || c == 'F' || isL(c) }
+func isShebang(c byte) bool     { return isAlpha(c) || c == '_' || c == '/' }
+
+// isRegular matches [[:alnum:]_.@#/*]
+func isRegular(c byte) bool {
+	return isWord(c) || c == '.' || c == '@' || c == '#' || c == '/' || c == '*'
+}
+
+// isSGMLTag matches [[:alnum:]_!./?-]
+func isSGMLTag(c byte) bool {
+	return isWord(c) || c == '!' || c == '.' || c == '/' || c == '?' || c == '-'
+}
+
+func isPunctuation(c byte) bool {
+	switch c {
+	case ';', '{', '}', '(', ')', '[', ']':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Package tokenizer splits content into the lexical tokens enry and Linguist
+// classify files by, keeping the kind of each token and where it comes from.
+//
+// The tokens are the same as the ones the enry classifier uses, and the same
+// as Linguist's flex lexer returns.
+package tokenizer
+
+import "strconv"
+
+// ByteLimit defines the maximum prefix of an input text that will be tokenized.
+const ByteLimit = 100000
+
+// Kind is the lexical category of a Token.
+type Kind int
+
+const (
+	// Regular is an identifier, keyword or any other word-like token.
+	Regular Kind = iota
+	// Shebang is the interpreter of a shebang line, e.g. "SHEBANG#!python".
+	Shebang
+	// SGMLTag is an SGML, HTML or XML tag name, e.g. "<div>".
+	SGMLTag
+	// SGMLAttribute is an attribute inside an SGML tag, e.g. "class=".
+	SGMLAttribute
+	// Punctuation is one of ;{}()[].
+	Punctuation
+	// Operator is one of << < + - % && & || |.
+	Operator
+	// Other is any other single character that is not blank. Tokenize
+	// never returns it, as Linguist's lexer drops those characters.
+	Other
+)
+
+var kindNames = [...]string{
+	Regular:       "Regular",
+	Shebang:       "Shebang",
+	SGMLTag:       "SGMLTag",
+	SGMLAttribute: "SGMLAttribute",
+	Punctuation:   "Punctuation",
+	Operator:      "Operator",
+	Other:         "Other",
+}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindNames[k]
+}
+
+// Token is a lexical token of some content.
+type Token struct {
+	Kind Kind
+	// Value is the token as the classifier sees it. It is the text the token
+	// comes from, except for Shebang and SGMLTag tokens, e.g. "<div>" for
+	// the text "<div".
+	Value string
+	// Start and End are the byte offsets of the text the token comes from,
+	// so that content[Start:End] is that text.
+	Start, End int
+}
+
+// Strings returns the values of the tokens, which is what the classifier uses.
+func Strings(tokens []Token) []string {
+	values := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		values = append(values, t.Value)
+	}
+
+	return values
+}
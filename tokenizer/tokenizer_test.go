@@ -0,0 +1,94 @@
+package tokenizer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	content := []byte(`#!/usr/bin/env python
+<div class="a" hidden>x</div>
+/* comment */ if (a && b) { return "s"; } é`)
+
+	expected := []Token{
+		{Shebang, "SHEBANG#!python", 15, 21},
+		{SGMLTag, "<div>", 22, 26},
+		{SGMLAttribute, "class=", 27, 33},
+		{SGMLAttribute, "hidden", 37, 43},
+		{Regular, "x", 44, 45},
+		{SGMLTag, "</div>", 45, 50},
+		{Regular, "if", 66, 68},
+		{Punctuation, "(", 69, 70},
+		{Regular, "a", 70, 71},
+		{Operator, "&&", 72, 74},
+		{Regular, "b", 75, 76},
+		{Punctuation, ")", 76, 77},
+		{Punctuation, "{", 78, 79},
+		{Regular, "return", 80, 86},
+		{Punctuation, ";", 90, 91},
+		{Punctuation, "}", 92, 93},
+	}
+
+	tokens := Tokenize(content)
+	assert.Equal(t, expected, tokens)
+	for _, token := range tokens {
+		if token.Kind != Shebang && token.Kind != SGMLTag {
+			assert.Equal(t, token.Value, string(content[token.Start:token.End]))
+		}
+	}
+}
+
+func TestTokenizeByteLimit(t *testing.T) {
+	content := make([]byte, ByteLimit+10)
+	for i := range content {
+		content[i] = ' '
+	}
+	copy(content[ByteLimit-3:], "foo bar")
+
+	assert.Equal(t, []Token{{Regular, "foo", ByteLimit - 3, ByteLimit}}, Tokenize(content))
+}
+
+func TestStrings(t *testing.T) {
+	assert.Equal(t, []string{"SHEBANG#!sh", "<a>"}, Strings(Tokenize([]byte("#!/bin/sh\n<a>"))))
+	assert.Equal(t, []string{}, Strings(nil))
+	assert.Equal(t, "SGMLAttribute", SGMLAttribute.String())
+	assert.Equal(t, "Other", Other.String())
+	assert.Equal(t, "Kind(42)", Kind(42).String())
+}
+
+func TestTokenizeLongTokens(t *testing.T) {
+	long := "a_token_longer_than_linguist_allows"
+	assert.Equal(t, []string{"short"}, Strings(Tokenize([]byte(long+" short"))))
+}
+
+func TestScanner(t *testing.T) {
+	content := []byte("#!/bin/sh\n<a href=\"x\">if (a && b) { return 1; }</a>\n")
+	tokens := Tokenize(content)
+
+	var s Scanner
+	assert.False(t, s.Scan())
+
+	s.Reset(content)
+	for _, token := range tokens {
+		require.True(t, s.Scan())
+		assert.Equal(t, token.Kind, s.Kind())
+		assert.Equal(t, token.Value, string(s.Bytes()))
+		start, end := s.Offsets()
+		assert.Equal(t, token.Start, start)
+		assert.Equal(t, token.End, end)
+	}
+	assert.False(t, s.Scan())
+
+	// the buffer for shebangs and tags is allocated once, whatever the number of tokens
+	content = bytes.Repeat(content, 100)
+	allocs := testing.AllocsPerRun(10, func() {
+		s.Reset(content)
+		for s.Scan() {
+			s.Bytes()
+		}
+	})
+	assert.Zero(t, allocs)
+}
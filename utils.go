@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/go-enry/go-enry/v2/data"
-	"github.com/go-enry/go-enry/v2/regex"
 )
 
 const binSniffLen = 8000
@@ -53,7 +52,7 @@ func GetMIMEType(path string, language string) string {
 
 // IsDocumentation returns whether or not path is a documentation path.
 func IsDocumentation(path string) bool {
-	return matchRegexSlice(data.DocumentationMatchers, path)
+	return data.DocumentationSet.MatchString(path)
 }
 
 // IsDotFile returns whether or not path has dot as a prefix.
@@ -64,36 +63,12 @@ func IsDotFile(path string) bool {
 
 // IsVendor returns whether or not path is a vendor path.
 func IsVendor(path string) bool {
-	// fast path: single collatated regex, if the engine supports its syntax
-	if data.FastVendorMatcher != nil {
-		return data.FastVendorMatcher.MatchString(path)
-	}
-
-	// slow path: skip individual rules with unsupported syntax
-	for _, matcher := range data.VendorMatchers {
-		if matcher == nil {
-			continue
-		}
-		if matcher.MatchString(path) {
-			return true
-		}
-	}
-	return false
+	return data.VendorSet.MatchString(path)
 }
 
 // IsTest returns whether or not path is a test path.
 func IsTest(path string) bool {
-	return matchRegexSlice(data.TestMatchers, path)
-}
-
-func matchRegexSlice(exprs []regex.EnryRegexp, str string) bool {
-	for _, expr := range exprs {
-		if expr.MatchString(str) {
-			return true
-		}
-	}
-
-	return false
+	return data.TestSet.MatchString(path)
 }
 
 // IsBinary detects if data is a binary value based on:
@@ -110,6 +85,33 @@ func IsBinary(data []byte) bool {
 	return true
 }
 
+// lfsPointerVersions are the first lines of the Git LFS pointer files, see
+// https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+var lfsPointerVersions = [][]byte{
+	[]byte("version https://git-lfs.github.com/spec/v1\n"),
+	[]byte("version https://hawser.github.com/spec/v1\n"),
+}
+
+// lfsPointerMaxSize is the size Git LFS pointer files are smaller than.
+const lfsPointerMaxSize = 1024
+
+// IsLFSPointer returns whether content is a Git LFS pointer file, standing
+// for a file stored out of the repository, rather than the file itself.
+func IsLFSPointer(content []byte) bool {
+	if len(content) >= lfsPointerMaxSize {
+		return false
+	}
+
+	for _, version := range lfsPointerVersions {
+		if bytes.HasPrefix(content, version) {
+			return bytes.Contains(content, []byte("\noid sha256:")) &&
+				bytes.Contains(content, []byte("\nsize "))
+		}
+	}
+
+	return false
+}
+
 // GetColor returns a HTML color code of a given language.
 func GetColor(language string) string {
 	if color, ok := data.LanguagesColor[language]; ok {
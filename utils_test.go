@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/go-enry/go-enry/v2/data"
 	"github.com/go-enry/go-enry/v2/regex"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -77,6 +79,76 @@ func BenchmarkIsVendor(b *testing.B) {
 	}
 }
 
+// pathMatchers are the sets used by IsVendor, IsDocumentation and IsTest
+// together with the individual matchers they are equivalent to.
+var pathMatchers = []struct {
+	name     string
+	set      *regex.Set
+	matchers []*regex.LazyRegexp
+}{
+	{"vendor", data.VendorSet, data.VendorMatchers},
+	{"documentation", data.DocumentationSet, data.DocumentationMatchers},
+	{"test", data.TestSet, data.TestMatchers},
+}
+
+func pathMatcherPaths() []string {
+	paths := []string{
+		"README", "docs/index.md", "src/Docs/x", "a/CHANGELOG.md", "Samples/x.c", "man/foo.1",
+		"test/FooTest.java", "foo_test.go", "foo.spec.ts", "src/foo.go", "lib/very/deep/path/to/some/file.rb",
+	}
+	for _, test := range vendorTests {
+		paths = append(paths, test.path)
+	}
+	return paths
+}
+
+// findFirst returns the index of the first matcher that matches path, as the
+// sets are expected to, by simply running them all in a loop.
+func findFirst(matchers []*regex.LazyRegexp, path string) int {
+	for i, m := range matchers {
+		if m.MatchString(path) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestPathMatcherSets(t *testing.T) {
+	for _, pm := range pathMatchers {
+		for _, path := range pathMatcherPaths() {
+			assert.Equal(t, findFirst(pm.matchers, path), pm.set.FindString(path), "%s set on %q", pm.name, path)
+		}
+	}
+}
+
+func BenchmarkPathMatchers(b *testing.B) {
+	paths := pathMatcherPaths()
+	for _, pm := range pathMatchers {
+		b.Run(pm.name+"/loop", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, path := range paths {
+					findFirst(pm.matchers, path)
+				}
+			}
+		})
+		b.Run(pm.name+"/set", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, path := range paths {
+					pm.set.FindString(path)
+				}
+			}
+		})
+	}
+
+	b.Run("vendor/collated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, path := range paths {
+				data.FastVendorMatcher.MatchString(path)
+			}
+		}
+	})
+}
+
 func TestIsDocumentation(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -165,6 +237,28 @@ func TestIsBinary(t *testing.T) {
 	}
 }
 
+func TestIsLFSPointer(t *testing.T) {
+	pointer := "version https://git-lfs.github.com/spec/v1\n" +
+		"oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\n" +
+		"size 12345\n"
+
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{name: "pointer", content: pointer, expected: true},
+		{name: "pre-release pointer", content: strings.Replace(pointer, "git-lfs.github.com", "hawser.github.com", 1), expected: true},
+		{name: "no oid", content: "version https://git-lfs.github.com/spec/v1\nsize 12345\n", expected: false},
+		{name: "too large", content: pointer + strings.Repeat("x", lfsPointerMaxSize), expected: false},
+		{name: "source", content: "package main\n", expected: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsLFSPointer([]byte(test.content)), test.name)
+	}
+}
+
 func TestIsDotFile(t *testing.T) {
 	tests := []struct {
 		name     string